@@ -0,0 +1,217 @@
+// Command noisegen renders noise from package rand to PNG images, without
+// needing a window like the examples in package rand.
+//
+// The noise is either one of the built in generators, chosen with -type,
+// or a graph of modules loaded from a JSON file with -spec (see package
+// rand/module). The image covers [0,freq] horizontally, and the same scale
+// vertically, of the xy plane at -z.
+//
+// With -frames greater than 1, a sequence of images is written, moving
+// through the third dimension of the noise by -dz each frame. The frame
+// number is added to the output file name, so out.png becomes out_0000.png,
+// out_0001.png, and so on.
+//
+// Examples:
+//
+//	noisegen -type ridged -octaves 6 -colormap terrain -out ridged.png
+//	noisegen -type cell3d -metric manhattan -frames 60 -dz 0.02 -out cells.png
+//	noisegen -spec mountains.json -size 1024x512 -out mountains.png
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quillaja/goutil/rand"
+	"github.com/quillaja/goutil/rand/module"
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+// options set by the command line flags.
+type options struct {
+	typ         string
+	spec        string
+	seed        int64
+	freq        float64
+	octaves     int
+	lacunarity  float64
+	persistence float64
+	metric      string
+	colormap    string
+	width       int
+	height      int
+	low, high   float64
+	z, dz       float64
+	frames      int
+	out         string
+}
+
+// fractal modes available with -type.
+var fractals = map[string]rand.FractalMode{
+	"octaves":    rand.FBM,
+	"billow":     rand.Billow,
+	"turbulence": rand.Turbulence,
+	"ridged":     rand.Ridged,
+	"hybrid":     rand.HybridMulti,
+}
+
+// metrics available with -metric.
+var metrics = map[string]rand.Metric{
+	"euclidean":   rand.MetricEuclidean,
+	"euclideansq": rand.MetricEuclideanSq,
+	"manhattan":   rand.MetricManhattan,
+	"chebyshev":   rand.MetricChebyshev,
+}
+
+// colormaps available with -colormap.
+var colormaps = map[string]noisemap.Gradient{
+	"gray":    noisemap.Grayscale,
+	"terrain": noisemap.Terrain,
+}
+
+// makes the noise described by the options. concurrent is true if it's safe
+// to sample from several goroutines.
+func makeNoise(o *options) (n rand.Noise3D, concurrent bool, err error) {
+	if o.spec != "" {
+		b, err := os.ReadFile(o.spec)
+		if err != nil {
+			return nil, false, err
+		}
+		m, err := module.Unmarshal(b)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", o.spec, err)
+		}
+		// modules such as Cell keep state, so play it safe
+		return m, false, nil
+	}
+
+	if mode, ok := fractals[o.typ]; ok {
+		f := rand.NewFractal(rand.NewPerlin(o.seed), mode)
+		f.Octaves = o.octaves
+		f.Lacunarity = o.lacunarity
+		f.Persistence = o.persistence
+		return f, true, nil
+	}
+
+	metric, ok := metrics[o.metric]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown metric %q", o.metric)
+	}
+	switch o.typ {
+	case "perlin":
+		return rand.NewPerlin(o.seed), true, nil
+	case "value":
+		return rand.NewValueNoise(o.seed, nil), true, nil
+	case "gradient":
+		return rand.NewGradientNoise(o.seed, nil), true, nil
+	case "cell2d":
+		w := rand.NewWorley2D(o.seed, 2, 5, metric)
+		return rand.Signed(rand.Lift2D(rand.Noise2DFunc(w.Noise))), true, nil
+	case "cell3d":
+		return rand.Signed(rand.NewWorley3D(o.seed, 2, 5, metric)), true, nil
+	}
+	return nil, false, fmt.Errorf("unknown type %q", o.typ)
+}
+
+// renders the slice of n at z to an image.
+func render(n rand.Noise3D, concurrent bool, z float64, o *options, g noisemap.Gradient) image.Image {
+	slice := rand.Noise2DFunc(func(x, y float64) float64 {
+		return n.Noise(x, y, z)
+	})
+	r := noisemap.Rect{MaxX: o.freq, MaxY: o.freq * float64(o.height) / float64(o.width)}
+
+	var m *noisemap.Map
+	if concurrent {
+		m = noisemap.Sample(slice, r, o.width, o.height)
+	} else {
+		m = noisemap.SampleSerial(slice, r, o.width, o.height)
+	}
+
+	// scale [low,high] to the gradient's [-1,1]
+	for i, v := range m.Values {
+		m.Values[i] = 2*(v-o.low)/(o.high-o.low) - 1
+	}
+	return m.RGBA(g)
+}
+
+// gets the name of frame i of a sequence written to out.
+func frameName(out string, i int) string {
+	ext := filepath.Ext(out)
+	return fmt.Sprintf("%s_%04d%s", strings.TrimSuffix(out, ext), i, ext)
+}
+
+// writes img to a png file.
+func writePNG(name string, img image.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("noisegen: ")
+
+	var o options
+	var size string
+	flag.StringVar(&o.typ, "type", "perlin", "noise `type`: perlin, octaves, billow, turbulence, ridged, hybrid, value, gradient, cell2d or cell3d")
+	flag.StringVar(&o.spec, "spec", "", "JSON module spec `file` to render instead of -type")
+	flag.Int64Var(&o.seed, "seed", 1, "random seed")
+	flag.Float64Var(&o.freq, "freq", 4, "number of noise units across the image")
+	flag.IntVar(&o.octaves, "octaves", 4, "number of octaves for fractal types")
+	flag.Float64Var(&o.lacunarity, "lacunarity", 2, "frequency multiplier between octaves")
+	flag.Float64Var(&o.persistence, "persistence", 0.5, "amplitude multiplier between octaves")
+	flag.StringVar(&o.metric, "metric", "euclidean", "distance metric for cell types: euclidean, euclideansq, manhattan or chebyshev")
+	flag.StringVar(&o.colormap, "colormap", "gray", "colormap: gray or terrain")
+	flag.StringVar(&size, "size", "512x512", "image size, `WxH`")
+	flag.Float64Var(&o.low, "low", -1, "noise value mapped to the bottom of the colormap")
+	flag.Float64Var(&o.high, "high", 1, "noise value mapped to the top of the colormap")
+	flag.Float64Var(&o.z, "z", 0, "z coordinate of the (first) image")
+	flag.Float64Var(&o.dz, "dz", 0.05, "change in z between frames")
+	flag.IntVar(&o.frames, "frames", 1, "number of frames to render")
+	flag.StringVar(&o.out, "out", "noise.png", "output `file`")
+	flag.Parse()
+
+	if _, err := fmt.Sscanf(size, "%dx%d", &o.width, &o.height); err != nil || o.width <= 0 || o.height <= 0 {
+		log.Fatalf("invalid size %q", size)
+	}
+	if o.low >= o.high {
+		log.Fatal("-low must be less than -high")
+	}
+	if o.octaves <= 0 {
+		log.Fatal("-octaves must be at least 1")
+	}
+	if o.frames <= 0 {
+		log.Fatal("-frames must be at least 1")
+	}
+	g, ok := colormaps[o.colormap]
+	if !ok {
+		log.Fatalf("unknown colormap %q", o.colormap)
+	}
+	n, concurrent, err := makeNoise(&o)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i := 0; i < o.frames; i++ {
+		name := o.out
+		if o.frames > 1 {
+			name = frameName(o.out, i)
+		}
+		img := render(n, concurrent, o.z+float64(i)*o.dz, &o, g)
+		if err := writePNG(name, img); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
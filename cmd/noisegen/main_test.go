@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+func TestFrameName(t *testing.T) {
+	tests := []struct {
+		out  string
+		i    int
+		want string
+	}{
+		{"noise.png", 0, "noise_0000.png"},
+		{"dir/out.png", 12, "dir/out_0012.png"},
+		{"noext", 3, "noext_0003"},
+	}
+	for _, tt := range tests {
+		if got := frameName(tt.out, tt.i); got != tt.want {
+			t.Errorf("frameName(%q, %d) = %q, want %q", tt.out, tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestMakeNoise(t *testing.T) {
+	types := []string{"perlin", "octaves", "billow", "turbulence", "ridged", "hybrid", "value", "gradient", "cell2d", "cell3d"}
+	for _, typ := range types {
+		t.Run(typ, func(t *testing.T) {
+			o := &options{typ: typ, seed: 1, freq: 2, octaves: 3, lacunarity: 2, persistence: 0.5,
+				metric: "euclidean", width: 8, height: 4, low: -1, high: 1}
+			n, concurrent, err := makeNoise(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img := render(n, concurrent, 0.5, o, noisemap.Grayscale)
+			if b := img.Bounds(); b.Dx() != 8 || b.Dy() != 4 {
+				t.Errorf("image is %dx%d", b.Dx(), b.Dy())
+			}
+		})
+	}
+
+	if _, _, err := makeNoise(&options{typ: "nope", metric: "euclidean"}); err == nil {
+		t.Error("expected error for unknown type")
+	}
+	if _, _, err := makeNoise(&options{typ: "cell2d", metric: "nope"}); err == nil {
+		t.Error("expected error for unknown metric")
+	}
+}
+
+func TestMakeNoise_Spec(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "spec.json")
+	spec := `{"type": "scalebias", "params": {"scale": 0.5}, "sources": [{"type": "perlin", "seed": 3}]}`
+	if err := os.WriteFile(name, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	n, concurrent, err := makeNoise(&options{spec: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if concurrent {
+		t.Error("specs should be sampled serially")
+	}
+	if v := n.Noise(0.3, 0.7, 0.1); v < -0.5 || 0.5 < v {
+		t.Errorf("%g not in [-0.5,0.5]", v)
+	}
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"math"
 	"math/rand"
 	"reflect"
 	"sort"
@@ -196,3 +197,27 @@ func bruteForceNN(dist DistanceMetric, k int, items []Interface, search Interfac
 
 	return
 }
+
+func TestDistanceMetricsOf(t *testing.T) {
+	a, b := []float64{1, -2, 3.5}, []float64{-4, 0.5, 2}
+	a32, b32 := []float32{1, -2, 3.5}, []float32{-4, 0.5, 2}
+	tests := []struct {
+		name string
+		f64  DistanceMetric
+		f32  func(a, b []float32) float32
+	}{
+		{"euclidean sq", EuclideanSq, EuclideanSqOf[float32]},
+		{"euclidean", Euclidean, EuclideanOf[float32]},
+		{"manhattan", Manhattan, ManhattanOf[float32]},
+		{"chebyshev", Chebyshev, ChebyshevOf[float32]},
+		{"canberra", Canberra, CanberraOf[float32]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.f64(a, b)
+			if got := float64(tt.f32(a32, b32)); math.Abs(got-want) > 1e-5*want {
+				t.Errorf("got %g, want %g", got, want)
+			}
+		})
+	}
+}
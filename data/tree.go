@@ -2,6 +2,8 @@ package data
 
 import (
 	"math"
+
+	"github.com/quillaja/goutil/num"
 )
 
 // Interface defines methods required for type wishing to use SpacialTree.
@@ -40,10 +42,38 @@ type DistanceMetric func([]float64, []float64) float64
 // euclidean/cartesian/geometric distance. It actually returns the sum of
 // squares, without taking the square root.
 func EuclideanSq(a, b []float64) float64 {
+	return EuclideanSqOf(a, b)
+}
+
+// Euclidean is the same as EuclideanSq() but takes the square root.
+func Euclidean(a, b []float64) float64 {
+	return EuclideanOf(a, b)
+}
+
+// Manhattan is a DistanceMetric func which computes the
+// manhattan/taxi cab/snake distance.
+func Manhattan(a, b []float64) float64 {
+	return ManhattanOf(a, b)
+}
+
+// Chebyshev is a DistanceMetric func which computes the chebyshev distance,
+// where the distance is the single most significant of the components.
+func Chebyshev(a, b []float64) float64 {
+	return ChebyshevOf(a, b)
+}
+
+// Canberra is a DistanceMetric func which computes the canberra distance:
+// Sum( |b-a| / |b|+|a| )
+func Canberra(a, b []float64) float64 {
+	return CanberraOf(a, b)
+}
+
+// EuclideanSqOf is the same as EuclideanSq() but works on float32 as well as float64.
+func EuclideanSqOf[T num.Float](a, b []T) T {
 	if len(a) != len(b) {
 		panic("a and b are different lengths")
 	}
-	sum := 0.0
+	var sum T
 	for i := 0; i < len(a); i++ {
 		diff := b[i] - a[i]
 		sum += diff * diff
@@ -51,49 +81,46 @@ func EuclideanSq(a, b []float64) float64 {
 	return sum
 }
 
-// Euclidean is the same as EuclideanSq() but takes the square root.
-func Euclidean(a, b []float64) float64 {
-	return math.Sqrt(EuclideanSq(a, b))
+// EuclideanOf is the same as Euclidean() but works on float32 as well as float64.
+func EuclideanOf[T num.Float](a, b []T) T {
+	return T(math.Sqrt(float64(EuclideanSqOf(a, b))))
 }
 
-// Manhattan is a DistanceMetric func which computes the
-// manhattan/taxi cab/snake distance.
-func Manhattan(a, b []float64) float64 {
+// ManhattanOf is the same as Manhattan() but works on float32 as well as float64.
+func ManhattanOf[T num.Float](a, b []T) T {
 	if len(a) != len(b) {
 		panic("a and b are different lengths")
 	}
-	sum := 0.0
+	var sum T
 	for i := 0; i < len(a); i++ {
 		diff := b[i] - a[i]
-		sum += math.Abs(diff)
+		sum += T(math.Abs(float64(diff)))
 	}
 	return sum
 }
 
-// Chebyshev is a DistanceMetric func which computes the chebyshev distance,
-// where the distance is the single most significant of the components.
-func Chebyshev(a, b []float64) float64 {
+// ChebyshevOf is the same as Chebyshev() but works on float32 as well as float64.
+func ChebyshevOf[T num.Float](a, b []T) T {
 	if len(a) != len(b) {
 		panic("a and b are different lengths")
 	}
-	max := 0.0
+	var max T
 	for i := 0; i < len(a); i++ {
 		diff := b[i] - a[i]
-		max = math.Max(max, math.Abs(diff))
+		max = T(math.Max(float64(max), math.Abs(float64(diff))))
 	}
 	return max
 }
 
-// Canberra is a DistanceMetric func which computes the canberra distance:
-// Sum( |b-a| / |b|+|a| )
-func Canberra(a, b []float64) float64 {
+// CanberraOf is the same as Canberra() but works on float32 as well as float64.
+func CanberraOf[T num.Float](a, b []T) T {
 	if len(a) != len(b) {
 		panic("a and b are different lengths")
 	}
-	sum := 0.0
+	var sum T
 	for i := 0; i < len(a); i++ {
 		diff := b[i] - a[i]
-		sum += math.Abs(diff) / (math.Abs(b[i]) + math.Abs(a[i]))
+		sum += T(math.Abs(float64(diff)) / (math.Abs(float64(b[i])) + math.Abs(float64(a[i]))))
 	}
 	return sum
 }
@@ -0,0 +1,34 @@
+package num
+
+// Signed is a constraint for the signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint for the unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint for all the integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint for the floating point types, so that functions
+// can work with float32 as well as float64 without conversions.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint for all the integer and floating point types.
+type Number interface {
+	Integer | Float
+}
+
+// Ordered is a constraint for types that can be compared with < and >.
+// It's the same as the one in golang.org/x/exp/constraints, defined here
+// so num has no dependencies.
+type Ordered interface {
+	Integer | Float | ~string
+}
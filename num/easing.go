@@ -0,0 +1,297 @@
+package num
+
+import (
+	"fmt"
+	"math"
+)
+
+// Easing functions map time t in [0,1] to progress in about [0,1], starting
+// at 0 and ending at 1, to make animations speed up and slow down naturally.
+// The "In" functions start slowly, the "Out" functions end slowly, and the
+// "InOut" functions do both. Back and Elastic overshoot, so their results go
+// a bit outside of [0,1].
+//
+// See: http://robertpenner.com/easing/
+// and: https://easings.net/
+
+// constants for the Back and Elastic easings
+const (
+	backC1    = 1.70158
+	backC2    = backC1 * 1.525
+	elasticC4 = 2 * math.Pi / 3
+	elasticC5 = 2 * math.Pi / 4.5
+)
+
+// Linear is the identity easing: it returns t.
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad eases in with t^2.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad eases out with t^2.
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad eases in and out with t^2.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	u := -2*t + 2
+	return 1 - u*u/2
+}
+
+// EaseInCubic eases in with t^3.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic eases out with t^3.
+func EaseOutCubic(t float64) float64 {
+	u := 1 - t
+	return 1 - u*u*u
+}
+
+// EaseInOutCubic eases in and out with t^3.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	u := -2*t + 2
+	return 1 - u*u*u/2
+}
+
+// EaseInQuart eases in with t^4.
+func EaseInQuart(t float64) float64 {
+	return t * t * t * t
+}
+
+// EaseOutQuart eases out with t^4.
+func EaseOutQuart(t float64) float64 {
+	u := 1 - t
+	return 1 - u*u*u*u
+}
+
+// EaseInOutQuart eases in and out with t^4.
+func EaseInOutQuart(t float64) float64 {
+	if t < 0.5 {
+		return 8 * t * t * t * t
+	}
+	u := -2*t + 2
+	return 1 - u*u*u*u/2
+}
+
+// EaseInQuint eases in with t^5.
+func EaseInQuint(t float64) float64 {
+	return t * t * t * t * t
+}
+
+// EaseOutQuint eases out with t^5.
+func EaseOutQuint(t float64) float64 {
+	u := 1 - t
+	return 1 - u*u*u*u*u
+}
+
+// EaseInOutQuint eases in and out with t^5.
+func EaseInOutQuint(t float64) float64 {
+	if t < 0.5 {
+		return 16 * t * t * t * t * t
+	}
+	u := -2*t + 2
+	return 1 - u*u*u*u*u/2
+}
+
+// EaseInSine eases in with a quarter of a sine wave.
+func EaseInSine(t float64) float64 {
+	// the same as 1-cos(t*pi/2) but exactly 1 when t is 1
+	return 1 - math.Sin((1-t)*math.Pi/2)
+}
+
+// EaseOutSine eases out with a quarter of a sine wave.
+func EaseOutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// EaseInOutSine eases in and out with half of a cosine wave. It's the same
+// as CosineStep but isn't clamped.
+func EaseInOutSine(t float64) float64 {
+	return (1 - math.Cos(math.Pi*t)) / 2
+}
+
+// EaseInExpo eases in exponentially with 2^(10t-10).
+func EaseInExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Exp2(10*t - 10)
+}
+
+// EaseOutExpo eases out exponentially with 2^(-10t).
+func EaseOutExpo(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Exp2(-10*t)
+}
+
+// EaseInOutExpo eases in and out exponentially.
+func EaseInOutExpo(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return math.Exp2(20*t-10) / 2
+	}
+	return (2 - math.Exp2(-20*t+10)) / 2
+}
+
+// EaseInCirc eases in with a quarter of a circle.
+func EaseInCirc(t float64) float64 {
+	return 1 - math.Sqrt(1-t*t)
+}
+
+// EaseOutCirc eases out with a quarter of a circle.
+func EaseOutCirc(t float64) float64 {
+	return math.Sqrt(1 - (t-1)*(t-1))
+}
+
+// EaseInOutCirc eases in and out with quarters of a circle.
+func EaseInOutCirc(t float64) float64 {
+	if t < 0.5 {
+		return (1 - math.Sqrt(1-4*t*t)) / 2
+	}
+	u := -2*t + 2
+	return (math.Sqrt(1-u*u) + 1) / 2
+}
+
+// EaseInBack pulls back a little below 0 before moving forward.
+func EaseInBack(t float64) float64 {
+	// c3*t^3 - c1*t^2, arranged so it's exactly 1 when t is 1
+	return t * t * (t + backC1*(t-1))
+}
+
+// EaseOutBack overshoots 1 a little before settling back.
+func EaseOutBack(t float64) float64 {
+	u := t - 1
+	return 1 + u*u*(u+backC1*(u+1))
+}
+
+// EaseInOutBack pulls back below 0 at the start and overshoots 1 at the end.
+func EaseInOutBack(t float64) float64 {
+	if t < 0.5 {
+		u := 2 * t
+		return u * u * (u + backC2*(u-1)) / 2
+	}
+	u := 2*t - 2
+	return (u*u*(u+backC2*(u+1)) + 2) / 2
+}
+
+// EaseInElastic wobbles around 0 with growing amplitude, like a plucked
+// string played backwards, before snapping to 1.
+func EaseInElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	return -math.Exp2(10*t-10) * math.Sin((10*t-10.75)*elasticC4)
+}
+
+// EaseOutElastic snaps past 1 and wobbles around it with shrinking amplitude.
+func EaseOutElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	return math.Exp2(-10*t)*math.Sin((10*t-0.75)*elasticC4) + 1
+}
+
+// EaseInOutElastic wobbles around 0 at the start and around 1 at the end.
+func EaseInOutElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return -math.Exp2(20*t-10) * math.Sin((20*t-11.125)*elasticC5) / 2
+	}
+	return math.Exp2(-20*t+10)*math.Sin((20*t-11.125)*elasticC5)/2 + 1
+}
+
+// EaseInBounce bounces with growing height before reaching 1.
+func EaseInBounce(t float64) float64 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// EaseOutBounce falls to 1 and bounces a few times, like a dropped ball.
+func EaseOutBounce(t float64) float64 {
+	const n1, d1 = 7.5625, 2.75
+	switch {
+	case t < 1/d1:
+		return n1 * t * t
+	case t < 2/d1:
+		t -= 1.5 / d1
+		return n1*t*t + 0.75
+	case t < 2.5/d1:
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375
+	}
+	t -= 2.625 / d1
+	return n1*t*t + 0.984375
+}
+
+// EaseInOutBounce bounces away from 0 and then toward 1.
+func EaseInOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - EaseOutBounce(1-2*t)) / 2
+	}
+	return (1 + EaseOutBounce(2*t-1)) / 2
+}
+
+// Reverse makes an easing that runs f backwards in time and upside down,
+// which turns an "In" easing into the matching "Out" easing and vice versa.
+// The result still goes from 0 to 1.
+func Reverse(f func(float64) float64) func(float64) float64 {
+	return func(t float64) float64 {
+		return 1 - f(1-t)
+	}
+}
+
+// Mirror makes an "InOut" easing from an "In" easing f by running f over
+// the first half of the time and Reverse(f) over the second half. It's the
+// same as Chain(f, Reverse(f)).
+func Mirror(f func(float64) float64) func(float64) float64 {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return f(2*t) / 2
+		}
+		return 1 - f(2-2*t)/2
+	}
+}
+
+// Chain makes an easing that runs each of the easings fs one after the
+// other. Each one gets an equal share of the time and of the progress from
+// 0 to 1, so with 2 easings the first goes from 0 to 0.5 while t goes from 0
+// to 0.5. It panics if fs is empty.
+func Chain(fs ...func(float64) float64) func(float64) float64 {
+	if len(fs) == 0 {
+		panic(fmt.Errorf("Invalid params: Chain needs at least 1 easing"))
+	}
+	fs = append([]func(float64) float64(nil), fs...)
+	n := float64(len(fs))
+	return func(t float64) float64 {
+		i := Clamp(int(math.Floor(t*n)), 0, len(fs)-1)
+		return (float64(i) + fs[i](t*n-float64(i))) / n
+	}
+}
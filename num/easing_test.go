@@ -0,0 +1,106 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+type easing = func(float64) float64
+
+var easingFamilies = []struct {
+	name           string
+	in, out, inOut easing
+	mirrors        bool // if inOut is Mirror(in)
+}{
+	{"Quad", EaseInQuad, EaseOutQuad, EaseInOutQuad, true},
+	{"Cubic", EaseInCubic, EaseOutCubic, EaseInOutCubic, true},
+	{"Quart", EaseInQuart, EaseOutQuart, EaseInOutQuart, true},
+	{"Quint", EaseInQuint, EaseOutQuint, EaseInOutQuint, true},
+	{"Sine", EaseInSine, EaseOutSine, EaseInOutSine, true},
+	{"Expo", EaseInExpo, EaseOutExpo, EaseInOutExpo, true},
+	{"Circ", EaseInCirc, EaseOutCirc, EaseInOutCirc, true},
+	{"Back", EaseInBack, EaseOutBack, EaseInOutBack, false},
+	{"Elastic", EaseInElastic, EaseOutElastic, EaseInOutElastic, false},
+	{"Bounce", EaseInBounce, EaseOutBounce, EaseInOutBounce, true},
+}
+
+func TestEasing_Endpoints(t *testing.T) {
+	check := func(name string, f easing) {
+		if got := f(0); got != 0 {
+			t.Errorf("%s(0) = %g, want 0", name, got)
+		}
+		if got := f(1); got != 1 {
+			t.Errorf("%s(1) = %g, want 1", name, got)
+		}
+	}
+	check("Linear", Linear)
+	for _, fam := range easingFamilies {
+		check("EaseIn"+fam.name, fam.in)
+		check("EaseOut"+fam.name, fam.out)
+		check("EaseInOut"+fam.name, fam.inOut)
+		check("Reverse(EaseIn"+fam.name+")", Reverse(fam.in))
+		check("Mirror(EaseIn"+fam.name+")", Mirror(fam.in))
+		check("Chain(EaseIn"+fam.name+", EaseOut"+fam.name+")", Chain(fam.in, fam.out))
+	}
+}
+
+func TestEasing_Shape(t *testing.T) {
+	const tol = 1e-12
+	for _, fam := range easingFamilies {
+		t.Run(fam.name, func(t *testing.T) {
+			if got := fam.inOut(0.5); math.Abs(got-0.5) > tol {
+				t.Errorf("InOut(0.5) = %g, want 0.5", got)
+			}
+			rev, mir := Reverse(fam.in), Mirror(fam.in)
+			for i := 0; i <= 100; i++ {
+				x := float64(i) / 100
+				if got, want := rev(x), fam.out(x); math.Abs(got-want) > tol {
+					t.Errorf("Reverse(In)(%g) = %g, Out = %g", x, got, want)
+				}
+				if got, want := fam.inOut(1-x), 1-fam.inOut(x); math.Abs(got-want) > tol {
+					t.Errorf("InOut isn't symmetric at %g: %g, %g", x, got, want)
+				}
+				if fam.mirrors {
+					if got, want := mir(x), fam.inOut(x); math.Abs(got-want) > tol {
+						t.Errorf("Mirror(In)(%g) = %g, InOut = %g", x, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	f := Chain(Linear, EaseInQuad, EaseOutQuad)
+	tests := []struct {
+		x, want float64
+	}{
+		{-1, -1},
+		{0, 0},
+		{1.0 / 6, 1.0 / 6},
+		{0.5, 1.0/3 + 0.25/3},
+		{2.0 / 3, 2.0 / 3},
+		{1, 1},
+		{2, 2.0/3 + EaseOutQuad(4)/3},
+	}
+	for _, tt := range tests {
+		if got := f(tt.x); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Chain(%g) = %g, want %g", tt.x, got, tt.want)
+		}
+	}
+
+	m, c := Mirror(EaseInBack), Chain(EaseInBack, Reverse(EaseInBack))
+	for i := 0; i <= 100; i++ {
+		x := float64(i) / 100
+		if a, b := m(x), c(x); math.Abs(a-b) > 1e-12 {
+			t.Errorf("Mirror(%g) = %g, Chain = %g", x, a, b)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Chain()
+}
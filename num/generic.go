@@ -0,0 +1,53 @@
+package num
+
+// Clamp clamps x between min and max.
+func Clamp[T Ordered](x, min, max T) T {
+	if x <= min {
+		return min
+	}
+	if x >= max {
+		return max
+	}
+	return x
+}
+
+// Min returns the smallest of its arguments.
+func Min[T Ordered](x T, rest ...T) T {
+	for _, y := range rest {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
+
+// Max returns the largest of its arguments.
+func Max[T Ordered](x T, rest ...T) T {
+	for _, y := range rest {
+		if y > x {
+			x = y
+		}
+	}
+	return x
+}
+
+// Abs returns the absolute value of x. Unlike math.Abs, Abs(-0.0) is -0.0.
+func Abs[T Number](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Sign returns -1 if x is negative, 1 if x is positive, and x itself
+// if it's zero or NaN.
+func Sign[T Number](x T) T {
+	var one T = 1
+	switch {
+	case x < 0:
+		return -one
+	case x > 0:
+		return one
+	}
+	return x
+}
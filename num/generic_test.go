@@ -0,0 +1,148 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		x, min, max, want float64
+	}{
+		{0.5, 0, 1, 0.5},
+		{-1, 0, 1, 0},
+		{2, 0, 1, 1},
+		{0, 0, 1, 0},
+		{1, 0, 1, 1},
+	}
+	for _, tt := range tests {
+		if got := Clamp(tt.x, tt.min, tt.max); got != tt.want {
+			t.Errorf("Clamp(%g, %g, %g) = %g, want %g", tt.x, tt.min, tt.max, got, tt.want)
+		}
+		if got := ClampFloat(tt.x, tt.min, tt.max); got != tt.want {
+			t.Errorf("ClampFloat(%g, %g, %g) = %g, want %g", tt.x, tt.min, tt.max, got, tt.want)
+		}
+		if got := ClampInt(int(tt.x), int(tt.min), int(tt.max)); got != int(tt.want) {
+			t.Errorf("ClampInt(%g, %g, %g) = %d, want %g", tt.x, tt.min, tt.max, got, tt.want)
+		}
+	}
+	if got := Clamp("m", "a", "f"); got != "f" {
+		t.Errorf("Clamp(string) = %q, want \"f\"", got)
+	}
+	if got := Clamp[uint8](200, 10, 100); got != 100 {
+		t.Errorf("Clamp(uint8) = %d, want 100", got)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		values   []int
+		min, max int
+	}{
+		{[]int{3}, 3, 3},
+		{[]int{3, 1, 2}, 1, 3},
+		{[]int{-5, 4, 4, -5}, -5, 4},
+	}
+	for _, tt := range tests {
+		if got := Min(tt.values[0], tt.values[1:]...); got != tt.min {
+			t.Errorf("Min(%v) = %d, want %d", tt.values, got, tt.min)
+		}
+		if got := Max(tt.values[0], tt.values[1:]...); got != tt.max {
+			t.Errorf("Max(%v) = %d, want %d", tt.values, got, tt.max)
+		}
+	}
+	if got := Min(float32(1.5), -2.5); got != -2.5 {
+		t.Errorf("Min(float32) = %g, want -2.5", got)
+	}
+}
+
+func TestAbsSign(t *testing.T) {
+	tests := []struct {
+		x, abs, sign float64
+	}{
+		{3.5, 3.5, 1},
+		{-3.5, 3.5, -1},
+		{0, 0, 0},
+		{math.Inf(-1), math.Inf(1), -1},
+	}
+	for _, tt := range tests {
+		if got := Abs(tt.x); got != tt.abs {
+			t.Errorf("Abs(%g) = %g, want %g", tt.x, got, tt.abs)
+		}
+		if got := Sign(tt.x); got != tt.sign {
+			t.Errorf("Sign(%g) = %g, want %g", tt.x, got, tt.sign)
+		}
+	}
+	if got := Sign(math.NaN()); !math.IsNaN(got) {
+		t.Errorf("Sign(NaN) = %g, want NaN", got)
+	}
+	if got := Abs(-7); got != 7 {
+		t.Errorf("Abs(-7) = %d, want 7", got)
+	}
+	if got := Sign[int8](-7); got != -1 {
+		t.Errorf("Sign(int8) = %d, want -1", got)
+	}
+	if got := Sign[uint](7); got != 1 {
+		t.Errorf("Sign(uint) = %d, want 1", got)
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		x, n, want float64
+	}{
+		{1.2, 0.5, 1},
+		{1.3, 0.5, 1.5},
+		{-7, 5, -5},
+		{7.5, 5, 10},
+		{-7.5, 5, -10},
+	}
+	for _, tt := range tests {
+		if got := RoundTo(tt.x, tt.n); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("RoundTo(%g, %g) = %g, want %g", tt.x, tt.n, got, tt.want)
+		}
+		if got := RoundToOf(float32(tt.x), float32(tt.n)); got != float32(tt.want) {
+			t.Errorf("RoundTo(float32 %g, %g) = %g, want %g", tt.x, tt.n, got, tt.want)
+		}
+	}
+
+	ints := []struct {
+		x, n, want int
+	}{
+		{12, 5, 10},
+		{13, 5, 15},
+		{-13, 5, -15},
+		{15, 10, 20},
+		{-15, 10, -20},
+		{7, 0, 0},
+	}
+	for _, tt := range ints {
+		if got := RoundToInt(tt.x, tt.n); got != tt.want {
+			t.Errorf("RoundToInt(%d, %d) = %d, want %d", tt.x, tt.n, got, tt.want)
+		}
+		if got := RoundToOf(int64(tt.x), int64(tt.n)); got != int64(tt.want) {
+			t.Errorf("RoundToOf(int64 %d, %d) = %d, want %d", tt.x, tt.n, got, tt.want)
+		}
+		if got := RoundToOf(tt.x, -tt.n); got != tt.want {
+			t.Errorf("RoundToOf(%d, %d) = %d, want %d", tt.x, -tt.n, got, tt.want)
+		}
+	}
+
+	// integers too big to be exact as float64
+	big := []struct {
+		x, n, want int64
+	}{
+		{1<<60 + 3, 2, 1<<60 + 4},
+		{1<<60 + 1, 4, 1 << 60},
+		{-(1<<62 + 7), 10, -(1<<62 + 6)},
+		{math.MaxInt64, 1, math.MaxInt64},
+	}
+	for _, tt := range big {
+		if got := RoundToOf(tt.x, tt.n); got != tt.want {
+			t.Errorf("RoundToOf(%d, %d) = %d, want %d", tt.x, tt.n, got, tt.want)
+		}
+	}
+	if got := RoundToOf[uint64](1<<63+5, 10); got != 1<<63+2 {
+		t.Errorf("RoundToOf(uint64) = %d, want %d", got, uint64(1<<63+2))
+	}
+}
@@ -0,0 +1,155 @@
+package num
+
+import (
+	"fmt"
+	"math"
+)
+
+// EdgeMode determines how the grid interpolation functions get samples from
+// outside of the grid.
+type EdgeMode int
+
+const (
+	// EdgeClamp uses the nearest sample on the edge of the grid.
+	EdgeClamp EdgeMode = iota
+	// EdgeWrap tiles the grid, so the sample after the last one is the
+	// first one. Use it for grids of periodic data such as tileable noise.
+	EdgeWrap
+	// EdgeMirror reflects the grid about its edge samples, so the sample
+	// before the first one is the second one.
+	EdgeMirror
+)
+
+// Bilinear interpolates between the 4 samples of a width x height grid
+// around (x, y). grid is in row-major order: the sample at (i, j) is
+// grid[j*width+i], which is how rand.FillGrid2D fills it. The coordinates
+// are in samples, so (1.5, 0) is halfway between the 2nd and 3rd samples of
+// the first row. Coordinates outside of the grid are handled according to
+// edge. Panics if grid is too small or a dimension is less than 1.
+//
+// See: https://en.wikipedia.org/wiki/Bilinear_interpolation
+func Bilinear(grid []float64, width, height int, x, y float64, edge EdgeMode) float64 {
+	checkGridSize(len(grid), width, height, 1)
+	i, fx := splitCoord(x)
+	j, fy := splitCoord(y)
+	i0, i1 := edgeIndex(i, width, edge), edgeIndex(i+1, width, edge)
+	j0, j1 := edgeIndex(j, height, edge)*width, edgeIndex(j+1, height, edge)*width
+	return UnitLerp(fy,
+		UnitLerp(fx, grid[j0+i0], grid[j0+i1]),
+		UnitLerp(fx, grid[j1+i0], grid[j1+i1]))
+}
+
+// Bicubic interpolates between the 16 samples of a width x height grid
+// around (x, y) with the Keys cubic convolution kernel with a = -0.5, which
+// is the same as Catmull-Rom interpolation. It's smoother than Bilinear and
+// goes through every sample, but can overshoot them a little. The grid,
+// coordinates and edge handling are the same as Bilinear.
+//
+// See: https://en.wikipedia.org/wiki/Bicubic_interpolation
+// and: "Cubic convolution interpolation for digital image processing" (R. Keys)
+func Bicubic(grid []float64, width, height int, x, y float64, edge EdgeMode) float64 {
+	checkGridSize(len(grid), width, height, 1)
+	i, fx := splitCoord(x)
+	j, fy := splitCoord(y)
+	var cols [4]int
+	for n := range cols {
+		cols[n] = edgeIndex(i+n-1, width, edge)
+	}
+	var rows [4]float64
+	for n := range rows {
+		row := edgeIndex(j+n-1, height, edge) * width
+		rows[n] = catmullRom(fx, grid[row+cols[0]], grid[row+cols[1]], grid[row+cols[2]], grid[row+cols[3]])
+	}
+	return catmullRom(fy, rows[0], rows[1], rows[2], rows[3])
+}
+
+// Trilinear interpolates between the 8 samples of a width x height x depth
+// grid around (x, y, z). grid is in row-major order: the sample at (i, j, k)
+// is grid[(k*height+j)*width+i], which is how rand.FillGrid3D fills it.
+// Otherwise it's the same as Bilinear.
+//
+// See: https://en.wikipedia.org/wiki/Trilinear_interpolation
+func Trilinear(grid []float64, width, height, depth int, x, y, z float64, edge EdgeMode) float64 {
+	checkGridSize(len(grid), width, height, depth)
+	i, fx := splitCoord(x)
+	j, fy := splitCoord(y)
+	k, fz := splitCoord(z)
+	i0, i1 := edgeIndex(i, width, edge), edgeIndex(i+1, width, edge)
+	j0, j1 := edgeIndex(j, height, edge), edgeIndex(j+1, height, edge)
+	k0, k1 := edgeIndex(k, depth, edge), edgeIndex(k+1, depth, edge)
+	lerpRow := func(j, k int) float64 {
+		row := (k*height + j) * width
+		return UnitLerp(fx, grid[row+i0], grid[row+i1])
+	}
+	return UnitLerp(fz,
+		UnitLerp(fy, lerpRow(j0, k0), lerpRow(j1, k0)),
+		UnitLerp(fy, lerpRow(j0, k1), lerpRow(j1, k1)))
+}
+
+// Barycentric gets the barycentric coordinates of p in the triangle abc,
+// which are the weights of a, b and c that give p. They add up to 1 and are
+// all in [0,1] if p is inside the triangle. A degenerate triangle gives
+// NaN or infinite weights.
+//
+// See: https://en.wikipedia.org/wiki/Barycentric_coordinate_system
+func Barycentric(p, a, b, c [2]float64) (wa, wb, wc float64) {
+	d := (b[1]-c[1])*(a[0]-c[0]) + (c[0]-b[0])*(a[1]-c[1])
+	wa = ((b[1]-c[1])*(p[0]-c[0]) + (c[0]-b[0])*(p[1]-c[1])) / d
+	wb = ((c[1]-a[1])*(p[0]-c[0]) + (a[0]-c[0])*(p[1]-c[1])) / d
+	return wa, wb, 1 - wa - wb
+}
+
+// InterpolateTriangle linearly interpolates the values va, vb and vc at the
+// corners of the triangle abc to the point p, using Barycentric. Points
+// outside of the triangle are extrapolated.
+func InterpolateTriangle(p, a, b, c [2]float64, va, vb, vc float64) float64 {
+	wa, wb, wc := Barycentric(p, a, b, c)
+	return wa*va + wb*vb + wc*vc
+}
+
+// Catmull-Rom interpolation between p1 and p2 with t in [0,1].
+func catmullRom(t, p0, p1, p2, p3 float64) float64 {
+	return p1 + 0.5*t*(p2-p0+t*(2*p0-5*p1+4*p2-p3+t*(3*(p1-p2)+p3-p0)))
+}
+
+// splits a coordinate into its integer part and its fraction in [0,1).
+func splitCoord(x float64) (int, float64) {
+	f := math.Floor(x)
+	return int(f), x - f
+}
+
+// gets the index in [0,n) of sample i according to the edge mode.
+func edgeIndex(i, n int, edge EdgeMode) int {
+	if 0 <= i && i < n {
+		return i
+	}
+	switch edge {
+	case EdgeWrap:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return i
+
+	case EdgeMirror:
+		if n == 1 {
+			return 0
+		}
+		period := 2 * (n - 1)
+		i %= period
+		if i < 0 {
+			i += period
+		}
+		if i >= n {
+			i = period - i
+		}
+		return i
+	}
+	return Clamp(i, 0, n-1)
+}
+
+func checkGridSize(n, width, height, depth int) {
+	if width < 1 || height < 1 || depth < 1 || n < width*height*depth {
+		panic(fmt.Errorf("Invalid params: %dx%dx%d grid doesn't fit in %d values", width, height, depth, n))
+	}
+}
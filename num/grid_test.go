@@ -0,0 +1,165 @@
+package num
+
+import (
+	"math"
+	"testing"
+)
+
+// makes a width x height x depth grid of f at each sample.
+func makeGrid(width, height, depth int, f func(x, y, z float64) float64) []float64 {
+	grid := make([]float64, width*height*depth)
+	for k := 0; k < depth; k++ {
+		for j := 0; j < height; j++ {
+			for i := 0; i < width; i++ {
+				grid[(k*height+j)*width+i] = f(float64(i), float64(j), float64(k))
+			}
+		}
+	}
+	return grid
+}
+
+func linear3(x, y, z float64) float64 {
+	return 2*x - 3*y + 0.5*z + 1
+}
+
+func TestGrid_Samples(t *testing.T) {
+	// interpolating at a sample gives the sample
+	const w, h, d = 5, 4, 3
+	grid := makeGrid(w, h, d, func(x, y, z float64) float64 { return math.Sin(x*7 + y*3 + z) })
+	for _, edge := range []EdgeMode{EdgeClamp, EdgeWrap, EdgeMirror} {
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				x, y, want := float64(i), float64(j), grid[j*w+i]
+				if got := Bilinear(grid, w, h, x, y, edge); got != want {
+					t.Errorf("Bilinear(%g, %g) = %g, want %g", x, y, got, want)
+				}
+				if got := Bicubic(grid, w, h, x, y, edge); math.Abs(got-want) > 1e-12 {
+					t.Errorf("Bicubic(%g, %g) = %g, want %g", x, y, got, want)
+				}
+				want = grid[(2*h+j)*w+i]
+				if got := Trilinear(grid, w, h, d, x, y, 2, edge); got != want {
+					t.Errorf("Trilinear(%g, %g, 2) = %g, want %g", x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGrid_Linear(t *testing.T) {
+	// all of them reproduce a linear function inside the grid
+	const w, h, d = 6, 5, 4
+	grid := makeGrid(w, h, d, linear3)
+	for n := 0; n < 200; n++ {
+		x := float64(n%17) / 17 * (w - 1)
+		y := float64(n%13) / 13 * (h - 1)
+		z := float64(n%7) / 7 * (d - 1)
+		want := linear3(x, y, 0)
+		if got := Bilinear(grid, w, h, x, y, EdgeClamp); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Bilinear(%g, %g) = %g, want %g", x, y, got, want)
+		}
+		// bicubic only away from the edges, where clamping breaks linearity
+		if 1 <= x && x <= w-2 && 1 <= y && y <= h-2 {
+			if got := Bicubic(grid, w, h, x, y, EdgeClamp); math.Abs(got-want) > 1e-12 {
+				t.Errorf("Bicubic(%g, %g) = %g, want %g", x, y, got, want)
+			}
+		}
+		want = linear3(x, y, z)
+		if got := Trilinear(grid, w, h, d, x, y, z, EdgeClamp); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Trilinear(%g, %g, %g) = %g, want %g", x, y, z, got, want)
+		}
+	}
+}
+
+func TestGrid_EdgeModes(t *testing.T) {
+	const w, h = 4, 3
+	grid := makeGrid(w, h, 1, linear3)
+	tests := []struct {
+		edge  EdgeMode
+		x, y  float64
+		sameX float64 // x inside the grid with the same value
+		sameY float64
+		name  string
+	}{
+		{EdgeClamp, -2.5, 1, 0, 1, "clamp left"},
+		{EdgeClamp, 7, 1.5, 3, 1.5, "clamp right"},
+		{EdgeClamp, 1.5, -1, 1.5, 0, "clamp top"},
+		{EdgeWrap, 4, 1, 0, 1, "wrap right"},
+		{EdgeWrap, -1, 2, 3, 2, "wrap left"},
+		{EdgeWrap, 2.5, 6, 2.5, 0, "wrap down"},
+		{EdgeMirror, -1, 1, 1, 1, "mirror left"},
+		{EdgeMirror, -0.5, 1, 0.5, 1, "mirror left half"},
+		{EdgeMirror, 4.25, 1, 1.75, 1, "mirror right"},
+		{EdgeMirror, 1, 3, 1, 1, "mirror bottom"},
+	}
+	for _, tt := range tests {
+		want := Bilinear(grid, w, h, tt.sameX, tt.sameY, tt.edge)
+		if got := Bilinear(grid, w, h, tt.x, tt.y, tt.edge); math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: Bilinear(%g, %g) = %g, want %g", tt.name, tt.x, tt.y, got, want)
+		}
+	}
+
+	// wrapping a tileable grid is seamless, so the cell between the last and
+	// first samples interpolates between them.
+	row := []float64{0, 1, 2, 3}
+	if got := Bilinear(row, 4, 1, 3.5, 0, EdgeWrap); got != 1.5 {
+		t.Errorf("Bilinear(3.5) wrapped = %g, want 1.5", got)
+	}
+	if got := Bicubic(row, 4, 1, 0, 7, EdgeWrap); got != 0 {
+		t.Errorf("Bicubic(0, 7) wrapped = %g, want 0", got)
+	}
+	// a single sample is constant with any mode
+	for _, edge := range []EdgeMode{EdgeClamp, EdgeWrap, EdgeMirror} {
+		if got := Bicubic([]float64{4}, 1, 1, -3.3, 2.1, edge); got != 4 {
+			t.Errorf("Bicubic(single sample, %d) = %g, want 4", edge, got)
+		}
+	}
+}
+
+func TestGrid_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"small grid", func() { Bilinear(make([]float64, 5), 2, 3, 0, 0, EdgeClamp) }},
+		{"zero width", func() { Bicubic(nil, 0, 3, 0, 0, EdgeClamp) }},
+		{"small 3D grid", func() { Trilinear(make([]float64, 8), 2, 2, 3, 0, 0, 0, EdgeClamp) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestBarycentric(t *testing.T) {
+	a, b, c := [2]float64{0, 0}, [2]float64{4, 1}, [2]float64{1, 3}
+	tests := []struct {
+		p          [2]float64
+		wa, wb, wc float64
+	}{
+		{a, 1, 0, 0},
+		{b, 0, 1, 0},
+		{c, 0, 0, 1},
+		{[2]float64{5.0 / 3, 4.0 / 3}, 1.0 / 3, 1.0 / 3, 1.0 / 3},
+		{[2]float64{2, 0.5}, 0.5, 0.5, 0},
+	}
+	for _, tt := range tests {
+		wa, wb, wc := Barycentric(tt.p, a, b, c)
+		if math.Abs(wa-tt.wa) > 1e-12 || math.Abs(wb-tt.wb) > 1e-12 || math.Abs(wc-tt.wc) > 1e-12 {
+			t.Errorf("Barycentric(%v) = %g, %g, %g, want %g, %g, %g", tt.p, wa, wb, wc, tt.wa, tt.wb, tt.wc)
+		}
+	}
+
+	// reproduces a linear function, inside the triangle or not
+	f := func(p [2]float64) float64 { return linear3(p[0], p[1], 0) }
+	for _, p := range [][2]float64{{1, 1}, {2, 1.5}, {-3, 7}} {
+		if got, want := InterpolateTriangle(p, a, b, c, f(a), f(b), f(c)), f(p); math.Abs(got-want) > 1e-12 {
+			t.Errorf("InterpolateTriangle(%v) = %g, want %g", p, got, want)
+		}
+	}
+}
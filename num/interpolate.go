@@ -1,4 +1,10 @@
 // Package num provides some numeric tools such as interpolation and clamping.
+//
+// Functions that work on several numeric types are generic and have plain
+// names, such as Clamp and Remap. Where a float64 function already had the
+// plain name, its generic version has the same name with an "Of" suffix,
+// such as SmoothStepOf. The generic versions of UnitLerp and ClampFloat are
+// Interpolate and Clamp.
 package num
 
 import "math"
@@ -31,25 +37,100 @@ func NormalizeAngleDeg(theta float64) float64 {
 // assumption that x is in the range [0.0, 1,0]. Essentially, a special case
 // of general linear interpolation.
 func UnitLerp(x, toMin, toMax float64) float64 {
-	return toMin + x*(toMax-toMin)
+	return Interpolate(x, toMin, toMax)
 }
 
-// ReverseUnitLerp interpolates an x in the range [xMin, xMax] to the range [0,1].
+// ReverseUnitLerp interpolates an x in the range [xMin, xMax] to the range [-1,0].
+//
+// Deprecated: ReverseUnitLerp is InverseLerp(x, xMin, xMax) - 1, which is
+// rarely what's wanted. Use InverseLerp instead.
 func ReverseUnitLerp(x, xMin, xMax float64) float64 {
 	return (x - xMax) / (xMax - xMin)
 }
 
-// Lerp does a linear interpolation of x to between toMin and toMax where xMin
-// and xMax are the lower and upper bounds of x.
+// Lerp does a linear interpolation of x to between toMax and toMin where xMin
+// and xMax are the lower and upper bounds of x. Note that xMin is mapped to
+// toMax and xMax to toMin.
+//
+// Deprecated: Lerp maps its ranges backwards. Use Remap instead, which maps
+// xMin to toMin. Lerp(x, xMin, xMax, toMin, toMax) is the same as
+// Remap(x, xMax, xMin, toMin, toMax).
 func Lerp(x, xMin, xMax, toMin, toMax float64) float64 {
 	return toMin + (toMax-toMin)*((xMax-x)/(xMax-xMin))
 }
 
 // SmoothStep uses a 3rd order polynomial to produce a smooth interpolation
 // of x to the range [0,1] when x is also in the range [0,1]. To interpolate
-// any x into a suitable argument for this function, use ReverseUnitLerp() first.
+// any x into a suitable argument for this function, use InverseLerp() first.
 // See: https://en.wikipedia.org/wiki/Smoothstep
 func SmoothStep(x float64) float64 {
+	return SmoothStepOf(x)
+}
+
+// SmootherStep uses a 5th order polynomial to produce a smooth interpolation
+// of x to the range [0,1] when x is also in the range [0,1].
+// See: https://en.wikipedia.org/wiki/Smoothstep
+func SmootherStep(x float64) float64 {
+	return SmootherStepOf(x)
+}
+
+// SmoothestStep uses a 7th order polynomial to produce a smooth interpolation
+// of x to the range [0,1] when x is also in the range [0,1].
+// See: https://en.wikipedia.org/wiki/Smoothstep
+func SmoothestStep(x float64) float64 {
+	return SmoothestStepOf(x)
+}
+
+// CosineStep uses half a cosine wave to produce a smooth interpolation
+// of x to the range [0,1] when x is also in the range [0,1]. It is about as
+// smooth as SmoothStep but slower.
+func CosineStep(x float64) float64 {
+	return CosineStepOf(x)
+}
+
+// Sigmoid returns the interpolation of x to the range [0,1] according to the
+// logistic function S(x) = 1 / (1 + e^-x).
+// See: https://en.wikipedia.org/wiki/Sigmoid_function
+func Sigmoid(x float64) float64 {
+	return SigmoidOf(x)
+}
+
+// ClampFloat clamps x between min and max.
+func ClampFloat(x, min, max float64) float64 {
+	return Clamp(x, min, max)
+}
+
+// Interpolate linearly interpolates between a and b, so t = 0 gives a and
+// t = 1 gives b. Values of t outside of [0,1] are extrapolated. It's the
+// generic version of UnitLerp, and InverseLerp is its inverse.
+func Interpolate[T Float](t, a, b T) T {
+	return a + t*(b-a)
+}
+
+// InverseLerp is the inverse of Interpolate and UnitLerp. It gives the position of x in the
+// range [a, b] as a fraction, so a gives 0 and b gives 1. Values of x outside
+// of the range give results outside of [0,1]. If a == b the result is NaN
+// or infinite.
+func InverseLerp[T Float](x, a, b T) T {
+	return (x - a) / (b - a)
+}
+
+// Remap linearly maps x from the range [inMin, inMax] to the range
+// [outMin, outMax], so inMin gives outMin and inMax gives outMax. Values of
+// x outside of the input range are extrapolated. Either range may be
+// reversed, e.g. inMin > inMax.
+func Remap[T Float](x, inMin, inMax, outMin, outMax T) T {
+	return Interpolate(InverseLerp(x, inMin, inMax), outMin, outMax)
+}
+
+// RemapClamped is the same as Remap but values of x outside of the input
+// range give the nearest end of the output range.
+func RemapClamped[T Float](x, inMin, inMax, outMin, outMax T) T {
+	return Interpolate(Clamp(InverseLerp(x, inMin, inMax), 0, 1), outMin, outMax)
+}
+
+// SmoothStepOf is the same as SmoothStep but works on float32 as well as float64.
+func SmoothStepOf[T Float](x T) T {
 	if x <= 0 {
 		return 0
 	}
@@ -59,10 +140,8 @@ func SmoothStep(x float64) float64 {
 	return x * x * (3 - 2*x)
 }
 
-// SmootherStep uses a 5th order polynomial to produce a smooth interpolation
-// of x to the range [0,1] when x is also in the range [0,1].
-// See: https://en.wikipedia.org/wiki/Smoothstep
-func SmootherStep(x float64) float64 {
+// SmootherStepOf is the same as SmootherStep but works on float32 as well as float64.
+func SmootherStepOf[T Float](x T) T {
 	if x <= 0 {
 		return 0
 	}
@@ -72,10 +151,8 @@ func SmootherStep(x float64) float64 {
 	return x * x * x * (x*(x*6-15) + 10)
 }
 
-// SmoothestStep uses a 7th order polynomial to produce a smooth interpolation
-// of x to the range [0,1] when x is also in the range [0,1].
-// See: https://en.wikipedia.org/wiki/Smoothstep
-func SmoothestStep(x float64) float64 {
+// SmoothestStepOf is the same as SmoothestStep but works on float32 as well as float64.
+func SmoothestStepOf[T Float](x T) T {
 	if x <= 0 {
 		return 0
 	}
@@ -85,20 +162,18 @@ func SmoothestStep(x float64) float64 {
 	return x * x * x * x * (x*(x*(x*-20+70)-84) + 35)
 }
 
-// Sigmoid returns the interpolation of x to the range [0,1] according to the
-// logistic function S(x) = 1 / (1 + e^-x).
-// See: https://en.wikipedia.org/wiki/Sigmoid_function
-func Sigmoid(x float64) float64 {
-	return 1 / (1 + math.Exp(-x))
-}
-
-// ClampFloat clamps x between min and max.
-func ClampFloat(x, min, max float64) float64 {
-	if x <= min {
-		return min
+// CosineStepOf is the same as CosineStep but works on float32 as well as float64.
+func CosineStepOf[T Float](x T) T {
+	if x <= 0 {
+		return 0
 	}
-	if x >= max {
-		return max
+	if 1 <= x {
+		return 1
 	}
-	return x
+	return 0.5 - 0.5*T(math.Cos(math.Pi*float64(x)))
+}
+
+// SigmoidOf is the same as Sigmoid but works on float32 as well as float64.
+func SigmoidOf[T Float](x T) T {
+	return 1 / (1 + T(math.Exp(-float64(x))))
 }
@@ -0,0 +1,107 @@
+package num
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// random ranges for the property tests. the ends are never too close
+// together, and are sometimes reversed.
+func randomRange(r *rand.Rand) (a, b float64) {
+	a = 200*r.Float64() - 100
+	b = a + (1+99*r.Float64())*float64(2*r.Intn(2)-1)
+	return a, b
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestInverseLerp_Property(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, b := randomRange(r)
+		u := 3*r.Float64() - 1 // includes extrapolation
+		if got := InverseLerp(Interpolate(u, a, b), a, b); !closeTo(got, u) {
+			t.Fatalf("InverseLerp(Interpolate(%g, %g, %g)) = %g", u, a, b, got)
+		}
+		x := 300*r.Float64() - 150
+		if got := Interpolate(InverseLerp(x, a, b), a, b); !closeTo(got, x) {
+			t.Fatalf("Interpolate(InverseLerp(%g, %g, %g)) = %g", x, a, b, got)
+		}
+		if got, want := Interpolate(float32(u), float32(a), float32(b)), UnitLerp(u, a, b); math.Abs(float64(got)-want) > 1e-3 {
+			t.Fatalf("Interpolate(float32 %g, %g, %g) = %g, want %g", u, a, b, got, want)
+		}
+	}
+}
+
+func TestRemap_Property(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		inMin, inMax := randomRange(r)
+		outMin, outMax := randomRange(r)
+		x := 300*r.Float64() - 150
+
+		// the ends map to the ends and remapping back gives x again
+		if got := Remap(inMin, inMin, inMax, outMin, outMax); !closeTo(got, outMin) {
+			t.Fatalf("Remap(inMin) = %g, want %g", got, outMin)
+		}
+		if got := Remap(inMax, inMin, inMax, outMin, outMax); !closeTo(got, outMax) {
+			t.Fatalf("Remap(inMax) = %g, want %g", got, outMax)
+		}
+		y := Remap(x, inMin, inMax, outMin, outMax)
+		if got := Remap(y, outMin, outMax, inMin, inMax); !closeTo(got, x) {
+			t.Fatalf("Remap back = %g, want %g", got, x)
+		}
+
+		// the clamped version agrees inside the range and stays in it outside
+		c := RemapClamped(x, inMin, inMax, outMin, outMax)
+		lo, hi := math.Min(outMin, outMax), math.Max(outMin, outMax)
+		if u := InverseLerp(x, inMin, inMax); 0 <= u && u <= 1 {
+			if !closeTo(c, y) {
+				t.Fatalf("RemapClamped(%g) = %g, want %g", x, c, y)
+			}
+		} else if c < lo || hi < c {
+			t.Fatalf("RemapClamped(%g) = %g, outside [%g, %g]", x, c, lo, hi)
+		}
+	}
+}
+
+func TestRemap(t *testing.T) {
+	tests := []struct {
+		x, inMin, inMax, outMin, outMax float64
+		want, clamped                   float64
+	}{
+		{0.5, 0, 1, 10, 20, 15, 15},
+		{-1, -1, 1, 0, 360, 0, 0},
+		{2, 0, 1, 10, 20, 30, 20},
+		{-1, 0, 1, 10, 20, 0, 10},
+		{0.25, 1, 0, 0, 100, 75, 75},
+		{2, 0, 1, 20, 10, 0, 10},
+	}
+	for _, tt := range tests {
+		if got := Remap(tt.x, tt.inMin, tt.inMax, tt.outMin, tt.outMax); got != tt.want {
+			t.Errorf("Remap(%v) = %g, want %g", tt, got, tt.want)
+		}
+		if got := RemapClamped(tt.x, tt.inMin, tt.inMax, tt.outMin, tt.outMax); got != tt.clamped {
+			t.Errorf("RemapClamped(%v) = %g, want %g", tt, got, tt.clamped)
+		}
+	}
+	if got := Remap[float32](0.5, 0, 2, 0, 8); got != 2 {
+		t.Errorf("Remap(float32) = %g, want 2", got)
+	}
+}
+
+func TestDeprecatedLerp(t *testing.T) {
+	// the old functions keep their old behavior
+	if got := Lerp(0, 0, 1, 10, 20); got != 20 {
+		t.Errorf("Lerp(0) = %g, want 20", got)
+	}
+	if got := Lerp(0.25, 0, 1, 10, 20); got != Remap(0.25, 1, 0, 10, 20) {
+		t.Errorf("Lerp(0.25) = %g, want %g", got, Remap(0.25, 1, 0, 10, 20))
+	}
+	if got := ReverseUnitLerp(0.25, 0, 1); got != InverseLerp(0.25, 0, 1)-1 {
+		t.Errorf("ReverseUnitLerp(0.25) = %g, want -0.75", got)
+	}
+}
@@ -6,10 +6,35 @@ import (
 
 // RoundTo rounds x to the nearest multiple of n.
 func RoundTo(x, n float64) float64 {
-	return n * math.Round(x/n)
+	return RoundToOf(x, n)
 }
 
 // RoundToInt rounds x to the nearest multiple of n.
 func RoundToInt(x, n int) int {
-	return n * int(math.Round(float64(x)/float64(n)))
+	return RoundToOf(x, n)
+}
+
+// RoundToOf is the same as RoundTo but works on any integer or floating
+// point type. Halfway values are rounded away from zero, as in math.Round.
+// For integers, rounding to a multiple of 0 gives 0.
+func RoundToOf[T Number](x, n T) T {
+	var half T = 1
+	if half /= 2; half != 0 {
+		return n * T(math.Round(float64(x)/float64(n)))
+	}
+	// integers are divided directly, since converting to float64 loses
+	// precision above 2^53. n == 0 gives 0, as RoundToInt always has.
+	if n == 0 {
+		return 0
+	}
+	q := x / n
+	r := x - q*n
+	if r != 0 && Abs(r) >= Abs(n)-Abs(r) {
+		if (r < 0) == (n < 0) {
+			q++
+		} else {
+			q--
+		}
+	}
+	return q * n
 }
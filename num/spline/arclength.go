@@ -0,0 +1,132 @@
+package spline
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ArcLength reparameterizes a curve by distance along it, so that points can
+// be placed evenly along the curve or moved along it at a constant speed,
+// which t alone doesn't do.
+type ArcLength struct {
+	curve Curve
+	// distance along the curve at t = i/(len(s)-1)
+	s []float64
+}
+
+// 5 point Gauss-Legendre quadrature on [-1,1]
+var (
+	gaussNodes   = [5]float64{0, -0.5384693101056831, 0.5384693101056831, -0.9061798459386640, 0.9061798459386640}
+	gaussWeights = [5]float64{0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891}
+)
+
+// NewArcLength measures the curve in the given number of pieces (samples).
+// Each piece is integrated numerically, so even a few samples per segment of
+// the curve give accurate lengths; more samples make Param faster and more
+// accurate where the curve's speed changes quickly. It panics if samples is
+// less than 1.
+func NewArcLength(c Curve, samples int) *ArcLength {
+	if samples < 1 {
+		panic(fmt.Errorf("Invalid params: samples must be at least 1"))
+	}
+	a := &ArcLength{curve: c, s: make([]float64, samples+1)}
+	for i := 1; i <= samples; i++ {
+		t0, t1 := float64(i-1)/float64(samples), float64(i)/float64(samples)
+		a.s[i] = a.s[i-1] + a.integrate(t0, t1)
+	}
+	return a
+}
+
+// integrates the speed of the curve from t0 to t1.
+func (a *ArcLength) integrate(t0, t1 float64) float64 {
+	mid, half := (t0+t1)/2, (t1-t0)/2
+	l := 0.0
+	for i, x := range gaussNodes {
+		l += gaussWeights[i] * a.speed(mid+half*x)
+	}
+	return half * l
+}
+
+func (a *ArcLength) speed(t float64) float64 {
+	d := a.curve.Derivative(t)
+	return math.Sqrt(dot(d, d))
+}
+
+// gets the index of the sample interval containing t, and its start.
+func (a *ArcLength) interval(t float64) (i int, t0 float64) {
+	n := len(a.s) - 1
+	i = int(t * float64(n))
+	if i > n-1 {
+		i = n - 1
+	}
+	return i, float64(i) / float64(n)
+}
+
+// Length gets the total length of the curve.
+func (a *ArcLength) Length() float64 {
+	return a.s[len(a.s)-1]
+}
+
+// Distance gets the distance along the curve from its start to t, which is
+// clamped to [0,1].
+func (a *ArcLength) Distance(t float64) float64 {
+	t = math.Max(0, math.Min(t, 1))
+	i, t0 := a.interval(t)
+	return a.s[i] + a.integrate(t0, t)
+}
+
+// Param gets the t at distance s along the curve from its start. It's the
+// inverse of Distance. s is clamped to [0, Length()].
+func (a *ArcLength) Param(s float64) float64 {
+	n := len(a.s) - 1
+	if s <= 0 {
+		return 0
+	}
+	if s >= a.Length() {
+		return 1
+	}
+	// find the sample interval, guess by linear interpolation within it,
+	// then refine with Newton's method, since d(distance)/dt is the speed.
+	i := sort.SearchFloat64s(a.s, s) - 1
+	if i < 0 {
+		i = 0
+	}
+	t0, t1 := float64(i)/float64(n), float64(i+1)/float64(n)
+	if a.s[i+1] == a.s[i] {
+		return t0
+	}
+	t := t0 + (t1-t0)*(s-a.s[i])/(a.s[i+1]-a.s[i])
+	for iter := 0; iter < 8; iter++ {
+		v := a.speed(t)
+		if v == 0 {
+			break
+		}
+		next := t - (a.s[i]+a.integrate(t0, t)-s)/v
+		next = math.Max(t0, math.Min(next, t1))
+		if math.Abs(next-t) < 1e-14 {
+			t = next
+			break
+		}
+		t = next
+	}
+	return t
+}
+
+// At gets the point at distance s along the curve from its start.
+func (a *ArcLength) At(s float64) []float64 {
+	return a.curve.At(a.Param(s))
+}
+
+// Points gets n points evenly spaced along the curve, including both ends.
+// It panics if n is less than 2.
+func (a *ArcLength) Points(n int) [][]float64 {
+	if n < 2 {
+		panic(fmt.Errorf("Invalid params: n must be at least 2"))
+	}
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = a.At(a.Length() * float64(i) / float64(n-1))
+	}
+	return pts
+}
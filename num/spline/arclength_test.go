@@ -0,0 +1,57 @@
+package spline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArcLength_Line(t *testing.T) {
+	// a line along x with a very uneven speed
+	line := NewBezier([][]float64{{0}, {0.95}, {1}})
+	a := NewArcLength(line, 8)
+	if got := a.Length(); math.Abs(got-1) > 1e-12 {
+		t.Errorf("Length() = %g, want 1", got)
+	}
+	for i := 0; i <= 20; i++ {
+		s := float64(i) / 20
+		if got := a.At(s)[0]; math.Abs(got-s) > 1e-10 {
+			t.Errorf("At(%g) = %g, want %g", s, got, s)
+		}
+		tt := a.Param(s)
+		if got := a.Distance(tt); math.Abs(got-s) > 1e-10 {
+			t.Errorf("Distance(Param(%g)) = %g", s, got)
+		}
+	}
+	if a.Param(-1) != 0 || a.Param(2) != 1 {
+		t.Errorf("Param isn't clamped: %g, %g", a.Param(-1), a.Param(2))
+	}
+}
+
+// a closed Catmull-Rom spline through points on a circle
+func circle(r float64, n int) *Cubic {
+	points := make([][]float64, n+3)
+	for i := range points {
+		theta := 2 * math.Pi * float64(i-1) / float64(n)
+		points[i] = []float64{r * math.Cos(theta), r * math.Sin(theta)}
+	}
+	return NewCatmullRom(points, Centripetal)
+}
+
+func TestArcLength_Circle(t *testing.T) {
+	a := NewArcLength(circle(2, 32), 64)
+	if got, want := a.Length(), 4*math.Pi; math.Abs(got-want) > 0.001*want {
+		t.Errorf("Length() = %g, want %g", got, want)
+	}
+
+	// evenly spaced points are about the same distance apart
+	pts := a.Points(17)
+	if !closeTo(pts[0], pts[16], 1e-9) {
+		t.Errorf("closed curve ends at %v, not %v", pts[16], pts[0])
+	}
+	want := distance(pts[0], pts[1])
+	for i := 1; i < len(pts); i++ {
+		if d := distance(pts[i-1], pts[i]); math.Abs(d-want) > 1e-6 {
+			t.Errorf("points %d and %d are %g apart, want %g", i-1, i, d, want)
+		}
+	}
+}
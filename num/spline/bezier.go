@@ -0,0 +1,65 @@
+package spline
+
+// Bezier is a Bezier curve of any degree. It starts at the first control
+// point and ends at the last, and is pulled toward the others.
+//
+// See: https://en.wikipedia.org/wiki/B%C3%A9zier_curve
+type Bezier struct {
+	points [][]float64
+	// control points of the first and second derivatives, which are
+	// Bezier curves of lower degree.
+	d1, d2 [][]float64
+}
+
+// NewBezier creates a Bezier curve from the control points. Its degree is
+// one less than the number of points. It panics if there are fewer than 2
+// points.
+func NewBezier(points [][]float64) *Bezier {
+	checkPoints(points, 2, "Bezier")
+	b := &Bezier{points: copyPoints(points)}
+	b.d1 = differences(b.points, float64(len(b.points)-1))
+	b.d2 = differences(b.d1, float64(len(b.d1)-1))
+	return b
+}
+
+// Degree gets the degree of the curve.
+func (b *Bezier) Degree() int {
+	return len(b.points) - 1
+}
+
+// At gets the point on the curve at t.
+func (b *Bezier) At(t float64) []float64 {
+	return deCasteljau(b.points, len(b.points[0]), t)
+}
+
+// Derivative gets the first derivative of the curve with respect to t.
+func (b *Bezier) Derivative(t float64) []float64 {
+	return deCasteljau(b.d1, len(b.points[0]), t)
+}
+
+// SecondDerivative gets the second derivative of the curve with respect to t.
+// It's always 0 for a line.
+func (b *Bezier) SecondDerivative(t float64) []float64 {
+	return deCasteljau(b.d2, len(b.points[0]), t)
+}
+
+// evaluates the Bezier curve with the given control points at t. it gives
+// the zero vector for no points.
+func deCasteljau(points [][]float64, dim int, t float64) []float64 {
+	if len(points) == 0 {
+		return make([]float64, dim)
+	}
+	work := make([]float64, len(points)*dim)
+	for i, p := range points {
+		copy(work[i*dim:], p)
+	}
+	for n := len(points) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			a, b := work[i*dim:(i+1)*dim], work[(i+1)*dim:(i+2)*dim]
+			for k := range a {
+				a[k] += t * (b[k] - a[k])
+			}
+		}
+	}
+	return work[:dim:dim]
+}
@@ -0,0 +1,48 @@
+package spline
+
+import "testing"
+
+func TestBezier(t *testing.T) {
+	p0, p1, p2 := []float64{0, 0}, []float64{1, 2}, []float64{3, 0}
+	b := NewBezier([][]float64{p0, p1, p2})
+	if b.Degree() != 2 {
+		t.Fatalf("Degree() = %d, want 2", b.Degree())
+	}
+	for i := 0; i <= 10; i++ {
+		x := float64(i) / 10
+		// (1-t)^2 p0 + 2t(1-t) p1 + t^2 p2
+		want := make([]float64, 2)
+		for k := range want {
+			want[k] = (1-x)*(1-x)*p0[k] + 2*x*(1-x)*p1[k] + x*x*p2[k]
+		}
+		if got := b.At(x); !closeTo(got, want, 1e-12) {
+			t.Errorf("At(%g) = %v, want %v", x, got, want)
+		}
+	}
+
+	// the derivative at the ends points at the neighboring control points
+	if got := b.Derivative(0); !closeTo(got, []float64{2, 4}, 1e-12) {
+		t.Errorf("Derivative(0) = %v, want [2 4]", got)
+	}
+	if got := b.Derivative(1); !closeTo(got, []float64{4, -4}, 1e-12) {
+		t.Errorf("Derivative(1) = %v, want [4 -4]", got)
+	}
+	if got := b.SecondDerivative(0.3); !closeTo(got, []float64{2, -8}, 1e-12) {
+		t.Errorf("SecondDerivative = %v, want [2 -8]", got)
+	}
+
+	// a line has no second derivative
+	line := NewBezier([][]float64{{0, 0, 0}, {1, 2, 3}})
+	if got := line.SecondDerivative(0.5); !closeTo(got, []float64{0, 0, 0}, 0) {
+		t.Errorf("line SecondDerivative = %v", got)
+	}
+}
+
+func TestBezier_Copies(t *testing.T) {
+	points := [][]float64{{0, 0}, {1, 1}}
+	b := NewBezier(points)
+	points[1][0] = 5
+	if got := b.At(1); !closeTo(got, []float64{1, 1}, 0) {
+		t.Errorf("At(1) = %v after changing points", got)
+	}
+}
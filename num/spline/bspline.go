@@ -0,0 +1,114 @@
+package spline
+
+import (
+	"fmt"
+	"math"
+)
+
+// BSpline is a uniform B-spline of any degree. It passes near, but usually
+// not through, its control points, and is as smooth as possible for its
+// degree: a cubic B-spline has a continuous second derivative everywhere.
+//
+// See: https://en.wikipedia.org/wiki/B-spline
+// and: https://pages.mtu.edu/~shene/COURSES/cs3621/NOTES/spline/B-spline/de-Boor.html
+type BSpline struct {
+	degree int
+	points [][]float64
+	// control points of the first and second derivatives, which are
+	// B-splines of lower degree.
+	d1, d2 [][]float64
+}
+
+// NewBSpline creates a uniform B-spline of the given degree, usually 3,
+// from the control points. The knots are evenly spaced, and the curve
+// covers the part of the knot range where it's fully defined, so it starts
+// and ends near, not at, the first and last points. It panics if the degree
+// is less than 1 or there are not more points than the degree.
+func NewBSpline(points [][]float64, degree int) *BSpline {
+	if degree < 1 {
+		panic(fmt.Errorf("Invalid params: B-spline degree must be at least 1"))
+	}
+	checkPoints(points, degree+1, "B-spline")
+	b := &BSpline{degree: degree, points: copyPoints(points)}
+	// with uniform knots the derivative control points are just the
+	// differences of the control points.
+	b.d1 = differences(b.points, 1)
+	if degree >= 2 {
+		b.d2 = differences(b.d1, 1)
+	}
+	return b
+}
+
+// Degree gets the degree of the curve.
+func (b *BSpline) Degree() int {
+	return b.degree
+}
+
+// Segments gets the number of polynomial segments in the curve.
+func (b *BSpline) Segments() int {
+	return len(b.points) - b.degree
+}
+
+// At gets the point on the curve at t.
+func (b *BSpline) At(t float64) []float64 {
+	return deBoor(b.points, b.degree, 0, b.knot(t))
+}
+
+// Derivative gets the first derivative of the curve with respect to t.
+func (b *BSpline) Derivative(t float64) []float64 {
+	d := deBoor(b.d1, b.degree-1, 1, b.knot(t))
+	scale(d, float64(b.Segments()))
+	return d
+}
+
+// SecondDerivative gets the second derivative of the curve with respect to t.
+// It's always 0 for degree 1.
+func (b *BSpline) SecondDerivative(t float64) []float64 {
+	if b.degree < 2 {
+		return make([]float64, len(b.points[0]))
+	}
+	d := deBoor(b.d2, b.degree-2, 2, b.knot(t))
+	n := float64(b.Segments())
+	scale(d, n*n)
+	return d
+}
+
+// converts t in [0,1] to a position in the knot vector.
+func (b *BSpline) knot(t float64) float64 {
+	return float64(b.degree) + t*float64(b.Segments())
+}
+
+// evaluates a B-spline with uniform knots j+offset at knot position x.
+func deBoor(points [][]float64, degree, offset int, x float64) []float64 {
+	dim := len(points[0])
+	// the knot span containing x, kept within the defined range
+	k := int(math.Floor(x)) - offset
+	if k < degree {
+		k = degree
+	}
+	if k > len(points)-1 {
+		k = len(points) - 1
+	}
+
+	work := make([]float64, (degree+1)*dim)
+	for j := 0; j <= degree; j++ {
+		copy(work[j*dim:], points[j+k-degree])
+	}
+	for r := 1; r <= degree; r++ {
+		for j := degree; j >= r; j-- {
+			// (x - knot[i]) / (knot[i+degree+1-r] - knot[i]) for uniform knots
+			alpha := (x - float64(j+k-degree+offset)) / float64(degree+1-r)
+			a, b := work[(j-1)*dim:j*dim], work[j*dim:(j+1)*dim]
+			for i := range b {
+				b[i] = (1-alpha)*a[i] + alpha*b[i]
+			}
+		}
+	}
+	return work[degree*dim : (degree+1)*dim : (degree+1)*dim]
+}
+
+func scale(v []float64, s float64) {
+	for i := range v {
+		v[i] *= s
+	}
+}
@@ -0,0 +1,53 @@
+package spline
+
+import "testing"
+
+func TestBSpline(t *testing.T) {
+	// degree 1 is the polyline through the points
+	b := NewBSpline(path2D, 1)
+	n := float64(b.Segments())
+	for i, p := range path2D {
+		if got := b.At(float64(i) / n); !closeTo(got, p, 1e-12) {
+			t.Errorf("degree 1: At(%g) = %v, want %v", float64(i)/n, got, p)
+		}
+	}
+	mid := []float64{(path2D[0][0] + path2D[1][0]) / 2, (path2D[0][1] + path2D[1][1]) / 2}
+	if got := b.At(0.5 / n); !closeTo(got, mid, 1e-12) {
+		t.Errorf("degree 1: At(%g) = %v, want %v", 0.5/n, got, mid)
+	}
+
+	// a uniform cubic starts each segment at (p0 + 4p1 + p2)/6
+	b = NewBSpline(path2D, 3)
+	if b.Segments() != len(path2D)-3 {
+		t.Fatalf("Segments() = %d, want %d", b.Segments(), len(path2D)-3)
+	}
+	n = float64(b.Segments())
+	for i := 0; i+2 < len(path2D); i++ {
+		p0, p1, p2 := path2D[i], path2D[i+1], path2D[i+2]
+		want := []float64{(p0[0] + 4*p1[0] + p2[0]) / 6, (p0[1] + 4*p1[1] + p2[1]) / 6}
+		if got := b.At(float64(i) / n); !closeTo(got, want, 1e-12) {
+			t.Errorf("degree 3: At(%g) = %v, want %v", float64(i)/n, got, want)
+		}
+	}
+}
+
+func TestBSpline_Smooth(t *testing.T) {
+	// a cubic B-spline's second derivative is continuous at the knots
+	b := NewBSpline(path3D, 3)
+	n := float64(b.Segments())
+	for i := 1; i < b.Segments(); i++ {
+		x := float64(i) / n
+		if l, r := b.SecondDerivative(x-1e-9), b.SecondDerivative(x+1e-9); !closeTo(l, r, 1e-6) {
+			t.Errorf("SecondDerivative jumps at %g: %v, %v", x, l, r)
+		}
+	}
+
+	// the same points give the same point
+	same := [][]float64{{2, 1}, {2, 1}, {2, 1}, {2, 1}, {2, 1}}
+	for degree := 1; degree <= 4; degree++ {
+		b := NewBSpline(same, degree)
+		if got := b.At(0.3); !closeTo(got, same[0], 1e-12) {
+			t.Errorf("degree %d: At(0.3) = %v, want %v", degree, got, same[0])
+		}
+	}
+}
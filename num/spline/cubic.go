@@ -0,0 +1,179 @@
+package spline
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Cubic is a curve made of cubic polynomial segments joined end to end, such
+// as a Hermite or Catmull-Rom spline.
+type Cubic struct {
+	// coefficients a, b, c, d of a + b*u + c*u^2 + d*u^3 for each segment,
+	// where u is in [0,1] over the segment.
+	segs [][4][]float64
+	// the t at the start of each segment, and 1 at the end.
+	knots []float64
+}
+
+// Parameters for NewCatmullRom. Uniform is the classic Catmull-Rom spline,
+// Centripetal never has cusps or self-intersections within a segment, and
+// Chordal follows the control points more tightly.
+const (
+	Uniform     = 0.0
+	Centripetal = 0.5
+	Chordal     = 1.0
+)
+
+// NewHermite creates a cubic Hermite spline that passes through each of
+// points with the matching tangent. Each segment gets an equal share of t.
+// The tangents are derivatives with respect to the parameter of each
+// segment, so a segment between points p0 and p1 with tangents m0 and m1 is
+//
+//	(2u^3 - 3u^2 + 1)p0 + (u^3 - 2u^2 + u)m0 + (-2u^3 + 3u^2)p1 + (u^3 - u^2)m1
+//
+// for u in [0,1]. It panics if there are fewer than 2 points or there
+// isn't a tangent for each point.
+//
+// See: https://en.wikipedia.org/wiki/Cubic_Hermite_spline
+func NewHermite(points, tangents [][]float64) *Cubic {
+	dim := checkPoints(points, 2, "Hermite")
+	if len(tangents) != len(points) {
+		panic(fmt.Errorf("Invalid params: Hermite needs a tangent for each point"))
+	}
+	for _, m := range tangents {
+		if len(m) != dim {
+			panic(fmt.Errorf("Invalid params: Hermite tangents must have the same length as the points"))
+		}
+	}
+	segs := make([][4][]float64, len(points)-1)
+	for i := range segs {
+		segs[i] = hermiteSegment(points[i], points[i+1], tangents[i], tangents[i+1])
+	}
+	return newCubic(segs, nil)
+}
+
+// NewCatmullRom creates a Catmull-Rom spline through points[1] to
+// points[len(points)-2]. The first and last points only control the
+// direction of the curve at its ends; repeat them to make the curve go
+// through them. Alpha sets how the segments are parameterized: each one gets
+// a share of t proportional to its length raised to alpha. 0 (Uniform) is the
+// classic spline, 0.5 (Centripetal) is usually the best choice, and 1
+// (Chordal) gives a nearly constant speed. It panics if there are fewer than
+// 4 points.
+//
+// See: https://en.wikipedia.org/wiki/Centripetal_Catmull%E2%80%93Rom_spline
+// and: "On the Parameterization of Catmull-Rom Curves" (C. Yuksel et al.)
+func NewCatmullRom(points [][]float64, alpha float64) *Cubic {
+	checkPoints(points, 4, "Catmull-Rom")
+	// distance between points raised to alpha, the knot interval used for
+	// the segment starting at each point.
+	dt := make([]float64, len(points)-1)
+	for i := range dt {
+		dt[i] = math.Pow(distance(points[i], points[i+1]), alpha)
+		if dt[i] == 0 {
+			// repeated points. the terms dividing by it are 0 anyway.
+			dt[i] = 1e-12
+		}
+	}
+
+	// the tangents at each end of a segment are found as in a non-uniform
+	// spline then scaled to the segment's own [0,1] parameter.
+	segs := make([][4][]float64, len(points)-3)
+	for i := range segs {
+		p0, p1, p2, p3 := points[i], points[i+1], points[i+2], points[i+3]
+		d0, d1, d2 := dt[i], dt[i+1], dt[i+2]
+		m1 := make([]float64, len(p0))
+		m2 := make([]float64, len(p0))
+		for k := range m1 {
+			m1[k] = d1 * ((p1[k]-p0[k])/d0 - (p2[k]-p0[k])/(d0+d1) + (p2[k]-p1[k])/d1)
+			m2[k] = d1 * ((p2[k]-p1[k])/d1 - (p3[k]-p1[k])/(d1+d2) + (p3[k]-p2[k])/d2)
+		}
+		segs[i] = hermiteSegment(p1, p2, m1, m2)
+	}
+	return newCubic(segs, dt[1:len(dt)-1])
+}
+
+// creates a Cubic where each segment's share of t is proportional to its
+// duration, or the same if durations is nil.
+func newCubic(segs [][4][]float64, durations []float64) *Cubic {
+	c := &Cubic{segs: segs, knots: make([]float64, len(segs)+1)}
+	total := 0.0
+	for i := range segs {
+		d := 1.0
+		if durations != nil {
+			d = durations[i]
+		}
+		total += d
+		c.knots[i+1] = total
+	}
+	for i := range c.knots {
+		c.knots[i] /= total
+	}
+	c.knots[len(segs)] = 1
+	return c
+}
+
+// gets the polynomial coefficients of a Hermite segment.
+func hermiteSegment(p0, p1, m0, m1 []float64) [4][]float64 {
+	var s [4][]float64
+	for j := range s {
+		s[j] = make([]float64, len(p0))
+	}
+	for k := range p0 {
+		s[0][k] = p0[k]
+		s[1][k] = m0[k]
+		s[2][k] = 3*(p1[k]-p0[k]) - 2*m0[k] - m1[k]
+		s[3][k] = 2*(p0[k]-p1[k]) + m0[k] + m1[k]
+	}
+	return s
+}
+
+// Segments gets the number of cubic segments in the curve.
+func (c *Cubic) Segments() int {
+	return len(c.segs)
+}
+
+// finds the segment containing t and the parameter u within it, and the
+// derivative du/dt.
+func (c *Cubic) segment(t float64) (i int, u, dudt float64) {
+	i = sort.SearchFloat64s(c.knots[1:], t) // first knot at or after t
+	if i > len(c.segs)-1 {
+		i = len(c.segs) - 1
+	}
+	dudt = 1 / (c.knots[i+1] - c.knots[i])
+	return i, (t - c.knots[i]) * dudt, dudt
+}
+
+// At gets the point on the curve at t.
+func (c *Cubic) At(t float64) []float64 {
+	i, u, _ := c.segment(t)
+	s := &c.segs[i]
+	p := make([]float64, len(s[0]))
+	for k := range p {
+		p[k] = s[0][k] + u*(s[1][k]+u*(s[2][k]+u*s[3][k]))
+	}
+	return p
+}
+
+// Derivative gets the first derivative of the curve with respect to t.
+func (c *Cubic) Derivative(t float64) []float64 {
+	i, u, n := c.segment(t)
+	s := &c.segs[i]
+	d := make([]float64, len(s[0]))
+	for k := range d {
+		d[k] = n * (s[1][k] + u*(2*s[2][k]+u*3*s[3][k]))
+	}
+	return d
+}
+
+// SecondDerivative gets the second derivative of the curve with respect to t.
+func (c *Cubic) SecondDerivative(t float64) []float64 {
+	i, u, n := c.segment(t)
+	s := &c.segs[i]
+	d := make([]float64, len(s[0]))
+	for k := range d {
+		d[k] = n * n * (2*s[2][k] + 6*u*s[3][k])
+	}
+	return d
+}
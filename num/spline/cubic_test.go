@@ -0,0 +1,89 @@
+package spline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHermite(t *testing.T) {
+	points := [][]float64{{0, 0}, {1, 1}, {3, 0}}
+	tangents := [][]float64{{1, 0}, {0, 2}, {-1, -1}}
+	c := NewHermite(points, tangents)
+	if c.Segments() != 2 {
+		t.Fatalf("Segments() = %d, want 2", c.Segments())
+	}
+	for i, p := range points {
+		x := float64(i) / 2
+		if got := c.At(x); !closeTo(got, p, 1e-12) {
+			t.Errorf("At(%g) = %v, want %v", x, got, p)
+		}
+		// tangents are per segment, and there are 2 segments in t
+		want := []float64{2 * tangents[i][0], 2 * tangents[i][1]}
+		if got := c.Derivative(x); !closeTo(got, want, 1e-12) {
+			t.Errorf("Derivative(%g) = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestCatmullRom(t *testing.T) {
+	for _, alpha := range []float64{Uniform, Centripetal, Chordal} {
+		c := NewCatmullRom(path2D, alpha)
+		if c.Segments() != len(path2D)-3 {
+			t.Fatalf("Segments() = %d, want %d", c.Segments(), len(path2D)-3)
+		}
+		// passes through all but the first and last points, at the knots
+		for i, p := range path2D[1 : len(path2D)-1] {
+			if got := c.At(c.knots[i]); !closeTo(got, p, 1e-9) {
+				t.Errorf("alpha %g: At(%g) = %v, want %v", alpha, c.knots[i], got, p)
+			}
+		}
+	}
+
+	// the uniform spline's tangent at a point is half the difference of its
+	// neighbors, per segment.
+	c := NewCatmullRom(path2D, Uniform)
+	n := float64(c.Segments())
+	for i := 1; i < len(path2D)-1; i++ {
+		prev, next := path2D[i-1], path2D[i+1]
+		want := []float64{n * (next[0] - prev[0]) / 2, n * (next[1] - prev[1]) / 2}
+		if got := c.Derivative(float64(i-1) / n); !closeTo(got, want, 1e-9) {
+			t.Errorf("Derivative at point %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestCatmullRom_Centripetal(t *testing.T) {
+	// uniform catmull-rom makes a loop between the middle points when the
+	// outer points are far away. centripetal doesn't.
+	points := [][]float64{{-10, -1}, {0, 0}, {1, 0}, {11, -1}}
+	loops := func(c *Cubic) bool {
+		for i := 0; i <= 100; i++ {
+			if c.Derivative(float64(i) / 100)[0] < 0 {
+				return true // moves backwards in x
+			}
+		}
+		return false
+	}
+	if !loops(NewCatmullRom(points, Uniform)) {
+		t.Error("expected uniform spline to loop")
+	}
+	if loops(NewCatmullRom(points, Centripetal)) {
+		t.Error("centripetal spline loops")
+	}
+
+	// repeated points are ok
+	c := NewCatmullRom([][]float64{{0, 0}, {0, 0}, {1, 1}, {2, 0}, {2, 0}}, Centripetal)
+	for i := 0; i <= 10; i++ {
+		for _, v := range c.At(float64(i) / 10) {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Fatalf("At(%g) = %v", float64(i)/10, c.At(float64(i)/10))
+			}
+		}
+	}
+	if got := c.At(0); !closeTo(got, []float64{0, 0}, 1e-12) {
+		t.Errorf("At(0) = %v, want [0 0]", got)
+	}
+	if got := c.At(1); !closeTo(got, []float64{2, 0}, 1e-12) {
+		t.Errorf("At(1) = %v, want [2 0]", got)
+	}
+}
@@ -0,0 +1,60 @@
+package spline
+
+import (
+	"fmt"
+	"math"
+)
+
+// Nearest finds the point on the curve (with t in [0,1]) nearest to p. It
+// returns the point's t and its distance from p. The curve is first sampled
+// at the given number of evenly spaced intervals, then the best sample is
+// refined with Newton's method. If the curve passes close to p in several
+// places, enough samples are needed to find the right one; a few per segment
+// of the curve is usually enough. It panics if samples is less than 1 or p
+// is the wrong length.
+func Nearest(c Curve, p []float64, samples int) (t, dist float64) {
+	if samples < 1 {
+		panic(fmt.Errorf("Invalid params: samples must be at least 1"))
+	}
+	if len(p) != len(c.At(0)) {
+		panic(fmt.Errorf("Invalid params: p has the wrong number of dimensions"))
+	}
+
+	best := 0
+	dist = math.Inf(1)
+	for i := 0; i <= samples; i++ {
+		if d := distance(c.At(float64(i)/float64(samples)), p); d < dist {
+			best, dist = i, d
+		}
+	}
+
+	// minimize f(t) = |c(t)-p|^2 with Newton's method, staying between the
+	// best sample's neighbors.
+	h := 1 / float64(samples)
+	t = float64(best) * h
+	lo, hi := math.Max(0, t-h), math.Min(1, t+h)
+	x := t
+	for iter := 0; iter < 16; iter++ {
+		q := c.At(x)
+		d1 := c.Derivative(x)
+		d2 := c.SecondDerivative(x)
+		for k := range q {
+			q[k] -= p[k]
+		}
+		f1 := dot(q, d1)
+		f2 := dot(d1, d1) + dot(q, d2)
+		if f2 <= 0 {
+			break // not near a minimum
+		}
+		next := math.Max(lo, math.Min(x-f1/f2, hi))
+		if math.Abs(next-x) < 1e-14 {
+			x = next
+			break
+		}
+		x = next
+	}
+	if d := distance(c.At(x), p); d < dist {
+		t, dist = x, d
+	}
+	return t, dist
+}
@@ -0,0 +1,40 @@
+package spline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearest(t *testing.T) {
+	c := circle(1, 16)
+	for i := 0; i < 50; i++ {
+		theta := 2 * math.Pi * float64(i) / 50
+		r := 0.5 + float64(i%5)*0.3
+		p := []float64{r * math.Cos(theta), r * math.Sin(theta)}
+
+		tt, dist := Nearest(c, p, 32)
+		if got := distance(c.At(tt), p); math.Abs(got-dist) > 1e-12 {
+			t.Errorf("distance %g doesn't match At(%g): %g", dist, tt, got)
+		}
+		// compare with brute force
+		best := math.Inf(1)
+		for j := 0; j <= 20000; j++ {
+			best = math.Min(best, distance(c.At(float64(j)/20000), p))
+		}
+		if dist > best+1e-9 {
+			t.Errorf("Nearest(%v) = %g, brute force found %g", p, dist, best)
+		}
+		if math.Abs(dist-math.Abs(r-1)) > 0.01 {
+			t.Errorf("Nearest(%v) = %g, want about %g", p, dist, math.Abs(r-1))
+		}
+	}
+
+	// the ends of an open curve
+	line := NewBezier([][]float64{{0, 0}, {1, 0}})
+	if tt, dist := Nearest(line, []float64{-1, 1}, 4); tt != 0 || math.Abs(dist-math.Sqrt2) > 1e-12 {
+		t.Errorf("Nearest before start = %g, %g", tt, dist)
+	}
+	if tt, dist := Nearest(line, []float64{0.25, 1}, 4); math.Abs(tt-0.25) > 1e-12 || math.Abs(dist-1) > 1e-12 {
+		t.Errorf("Nearest(0.25, 1) = %g, %g", tt, dist)
+	}
+}
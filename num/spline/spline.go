@@ -0,0 +1,79 @@
+// Package spline evaluates smooth curves defined by control points in any
+// number of dimensions, such as Catmull-Rom, Hermite, Bezier and B-splines.
+// Points are []float64, and every point of a curve must have the same length.
+package spline
+
+import (
+	"fmt"
+	"math"
+)
+
+// Curve is implemented by the curves in this package. All of them are
+// parameterized by t in [0,1], from the start of the curve to the end.
+// Values of t outside of [0,1] extrapolate the first or last piece of the
+// curve. Each method returns a new slice.
+type Curve interface {
+	// At gets the point on the curve at t.
+	At(t float64) []float64
+	// Derivative gets the first derivative of the curve with respect to t,
+	// which is the velocity of a point moving along it as t goes from 0 to 1.
+	Derivative(t float64) []float64
+	// SecondDerivative gets the second derivative of the curve with respect
+	// to t, which is the acceleration of a point moving along it.
+	SecondDerivative(t float64) []float64
+}
+
+// checks that there are at least min points, all with the same length,
+// and returns the length.
+func checkPoints(points [][]float64, min int, what string) int {
+	if len(points) < min {
+		panic(fmt.Errorf("Invalid params: %s needs at least %d points, got %d", what, min, len(points)))
+	}
+	dim := len(points[0])
+	for _, p := range points {
+		if len(p) != dim || dim == 0 {
+			panic(fmt.Errorf("Invalid params: %s points must have the same non-zero length", what))
+		}
+	}
+	return dim
+}
+
+// copies points so later changes to the caller's slices don't affect a curve.
+func copyPoints(points [][]float64) [][]float64 {
+	c := make([][]float64, len(points))
+	for i, p := range points {
+		c[i] = append([]float64(nil), p...)
+	}
+	return c
+}
+
+// gets the differences between consecutive points, scaled by s.
+func differences(points [][]float64, s float64) [][]float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	d := make([][]float64, len(points)-1)
+	for i := range d {
+		d[i] = make([]float64, len(points[i]))
+		for k := range d[i] {
+			d[i][k] = s * (points[i+1][k] - points[i][k])
+		}
+	}
+	return d
+}
+
+func dot(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		d += a[i] * b[i]
+	}
+	return d
+}
+
+func distance(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(d)
+}
@@ -0,0 +1,118 @@
+package spline
+
+import (
+	"math"
+	"testing"
+)
+
+// control points used by the tests: a wavy 2D path with a repeated point
+// and a 3D helix-like path.
+var (
+	path2D = [][]float64{{0, 0}, {1, 2}, {2, -1}, {3, 3}, {3, 3}, {5, 0}, {6, 1}}
+	path3D = [][]float64{{0, 0, 0}, {1, 1, 0.5}, {0, 2, 1}, {-1, 1, 1.5}, {0, 0, 2}, {1, 1, 2.5}}
+)
+
+func curveCases() []struct {
+	name   string
+	curve  Curve
+	smooth bool // if the first derivative is continuous
+} {
+	tangents := make([][]float64, len(path3D))
+	for i := range tangents {
+		tangents[i] = []float64{float64(i), 1, -float64(i) / 2}
+	}
+	return []struct {
+		name   string
+		curve  Curve
+		smooth bool
+	}{
+		{"Hermite", NewHermite(path3D, tangents), true},
+		{"CatmullRom uniform", NewCatmullRom(path2D, Uniform), true},
+		{"CatmullRom centripetal", NewCatmullRom(path2D, Centripetal), true},
+		{"CatmullRom chordal", NewCatmullRom(path3D, Chordal), true},
+		{"Bezier 1", NewBezier(path2D[:2]), true},
+		{"Bezier 3", NewBezier(path3D[:4]), true},
+		{"Bezier 6", NewBezier(path2D), true},
+		{"BSpline 1", NewBSpline(path2D, 1), false},
+		{"BSpline 2", NewBSpline(path3D, 2), true},
+		{"BSpline 3", NewBSpline(path2D, 3), true},
+		{"BSpline 5", NewBSpline(path3D, 5), true},
+	}
+}
+
+func closeTo(a, b []float64, tol float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// central difference of f at t
+func numericDerivative(f func(float64) []float64, t float64) []float64 {
+	const h = 1e-6
+	a, b := f(t+h), f(t-h)
+	for i := range a {
+		a[i] = (a[i] - b[i]) / (2 * h)
+	}
+	return a
+}
+
+func TestCurve_Derivatives(t *testing.T) {
+	// values of t that aren't near a segment boundary for any of the
+	// curves, since the second derivative can jump there.
+	ts := []float64{0.13, 0.41, 0.77, 0.93}
+	for _, tt := range curveCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, x := range ts {
+				if got, want := tt.curve.Derivative(x), numericDerivative(tt.curve.At, x); !closeTo(got, want, 1e-5) {
+					t.Errorf("Derivative(%g) = %v, want %v", x, got, want)
+				}
+				if got, want := tt.curve.SecondDerivative(x), numericDerivative(tt.curve.Derivative, x); !closeTo(got, want, 1e-5) {
+					t.Errorf("SecondDerivative(%g) = %v, want %v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCurve_Continuous(t *testing.T) {
+	for _, tt := range curveCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			const h = 1e-9
+			for i := 1; i < 60; i++ {
+				x := float64(i) / 60
+				if a, b := tt.curve.At(x-h), tt.curve.At(x+h); !closeTo(a, b, 1e-6) {
+					t.Errorf("At jumps at %g: %v, %v", x, a, b)
+				}
+				if a, b := tt.curve.Derivative(x-h), tt.curve.Derivative(x+h); tt.smooth && !closeTo(a, b, 1e-6) {
+					t.Errorf("Derivative jumps at %g: %v, %v", x, a, b)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPoints_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"too few", func() { NewBezier(path2D[:1]) }},
+		{"mixed dims", func() { NewCatmullRom([][]float64{{0, 0}, {1, 1}, {2}, {3, 3}}, Uniform) }},
+		{"missing tangents", func() { NewHermite(path2D, path2D[1:]) }},
+		{"bad degree", func() { NewBSpline(path2D, 0) }},
+		{"few for degree", func() { NewBSpline(path2D[:3], 3) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+// Report is a summary of the quality of a map of noise.
+type Report struct {
+	Stats     Stats
+	Histogram *Histogram // 20 bins over [-1,1]
+	// from the power spectrum
+	Radial     []float64
+	Centroid   float64
+	Anisotropy float64 // over 8 sectors
+	SeamError  float64
+}
+
+// Analyze makes a report on m. The map's size must be powers of 2 for the
+// power spectrum.
+func Analyze(m *noisemap.Map) *Report {
+	s := PowerSpectrum(m)
+	return &Report{
+		Stats:      Describe(m.Values),
+		Histogram:  NewHistogram(m.Values, -1, 1, 20),
+		Radial:     s.Radial(),
+		Centroid:   s.Centroid(),
+		Anisotropy: s.Anisotropy(8),
+		SeamError:  SeamError(m),
+	}
+}
+
+// String formats the report for people to read.
+func (r *Report) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, r.Stats)
+	fmt.Fprintf(b, "out of [-1,1]: %d below, %d above, %d NaN\n", r.Histogram.Under, r.Histogram.Over, r.Histogram.NaN)
+	for i, f := range r.Histogram.Fractions() {
+		lo, hi := r.Histogram.Bin(i)
+		fmt.Fprintf(b, "[%5.2f,%5.2f) %6.2f%% %s\n", lo, hi, 100*f, strings.Repeat("#", int(200*f)))
+	}
+	fmt.Fprintf(b, "spectral centroid %.3f, anisotropy %.3f, seam error %.3f\n", r.Centroid, r.Anisotropy, r.SeamError)
+	return b.String()
+}
@@ -0,0 +1,208 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+// Spectrum is the 2D power spectrum of a map.
+type Spectrum struct {
+	Width, Height int
+	// Power at each frequency, in the same layout as the map. The
+	// frequency at index (u, v) is given by Freq(u, v). The mean of the map
+	// is removed first, and the power is normalized so that it sums to the
+	// variance of the map.
+	Power []float64
+}
+
+// PowerSpectrum computes the power spectrum of m with an FFT. Panics if
+// the width or height of the map isn't a power of 2.
+func PowerSpectrum(m *noisemap.Map) *Spectrum {
+	if !isPow2(m.Width) || !isPow2(m.Height) {
+		panic(fmt.Errorf("Invalid params: map size %dx%d must be powers of 2", m.Width, m.Height))
+	}
+
+	mean := Describe(m.Values).Mean
+	c := make([]complex128, len(m.Values))
+	for i, v := range m.Values {
+		c[i] = complex(v-mean, 0)
+	}
+
+	// rows then columns
+	for y := 0; y < m.Height; y++ {
+		fft(c[y*m.Width : (y+1)*m.Width])
+	}
+	col := make([]complex128, m.Height)
+	for x := 0; x < m.Width; x++ {
+		for y := range col {
+			col[y] = c[y*m.Width+x]
+		}
+		fft(col)
+		for y, v := range col {
+			c[y*m.Width+x] = v
+		}
+	}
+
+	s := &Spectrum{Width: m.Width, Height: m.Height, Power: make([]float64, len(c))}
+	n := float64(len(c))
+	for i, v := range c {
+		a := cmplx.Abs(v) / n
+		s.Power[i] = a * a
+	}
+	return s
+}
+
+// Freq gets the signed frequency, in cycles across the map, at index (u, v).
+func (s *Spectrum) Freq(u, v int) (fx, fy int) {
+	fx, fy = u, v
+	if fx > s.Width/2 {
+		fx -= s.Width
+	}
+	if fy > s.Height/2 {
+		fy -= s.Height
+	}
+	return
+}
+
+// At gets the power at the signed frequency (fx, fy).
+func (s *Spectrum) At(fx, fy int) float64 {
+	u := (fx%s.Width + s.Width) % s.Width
+	v := (fy%s.Height + s.Height) % s.Height
+	return s.Power[v*s.Width+u]
+}
+
+// Total is the sum of the power, which is the variance of the map.
+func (s *Spectrum) Total() float64 {
+	t := 0.0
+	for _, p := range s.Power {
+		t += p
+	}
+	return t
+}
+
+// calls f for each frequency inside the largest circle that fits in the
+// spectrum, excluding the DC term.
+func (s *Spectrum) eachInDisk(f func(fx, fy int, r, p float64)) {
+	rmax := float64(min(s.Width, s.Height) / 2)
+	for v := 0; v < s.Height; v++ {
+		for u := 0; u < s.Width; u++ {
+			fx, fy := s.Freq(u, v)
+			r := math.Hypot(float64(fx), float64(fy))
+			if r == 0 || r > rmax {
+				continue
+			}
+			f(fx, fy, r, s.Power[v*s.Width+u])
+		}
+	}
+}
+
+// Radial gets the radially averaged power spectrum: element i is the mean
+// power of the frequencies whose magnitude rounds to i, for i up to half the
+// smaller dimension of the map. Element 0 is always 0.
+func (s *Spectrum) Radial() []float64 {
+	n := min(s.Width, s.Height)/2 + 1
+	sum, count := make([]float64, n), make([]int, n)
+	s.eachInDisk(func(fx, fy int, r, p float64) {
+		i := min(int(math.Round(r)), n-1)
+		sum[i] += p
+		count[i]++
+	})
+	for i := range sum {
+		if count[i] > 0 {
+			sum[i] /= float64(count[i])
+		}
+	}
+	return sum
+}
+
+// Centroid is the power-weighted mean frequency magnitude, a rough measure
+// of the size of the noise's features: higher means finer detail.
+func (s *Spectrum) Centroid() float64 {
+	var wsum, psum float64
+	s.eachInDisk(func(fx, fy int, r, p float64) {
+		wsum += r * p
+		psum += p
+	})
+	if psum == 0 {
+		return 0
+	}
+	return wsum / psum
+}
+
+// Directional gets the fraction of the power in each of the given number of
+// equal angular sectors of [0,PI). Sector 0 starts along the x axis, whose
+// frequencies correspond to features that vary along x. Since the spectrum
+// of a real map is symmetric, opposite directions are combined. Isotropic
+// noise has about the same power in every sector.
+func (s *Spectrum) Directional(sectors int) []float64 {
+	if sectors < 1 {
+		panic(fmt.Errorf("Invalid params: %d sectors", sectors))
+	}
+	d := make([]float64, sectors)
+	total := 0.0
+	s.eachInDisk(func(fx, fy int, r, p float64) {
+		a := math.Atan2(float64(fy), float64(fx))
+		if a < 0 {
+			a += math.Pi
+		}
+		i := int(a / math.Pi * float64(sectors))
+		d[i%sectors] += p
+		total += p
+	})
+	if total > 0 {
+		for i := range d {
+			d[i] /= total
+		}
+	}
+	return d
+}
+
+// Anisotropy measures how much the noise's power depends on direction, as
+// the difference between the largest and smallest sector of
+// Directional(sectors) relative to their mean. It's about 0 for isotropic
+// noise.
+func (s *Spectrum) Anisotropy(sectors int) float64 {
+	d := s.Directional(sectors)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range d {
+		lo, hi = math.Min(lo, p), math.Max(hi, p)
+	}
+	return (hi - lo) * float64(sectors) // mean is 1/sectors
+}
+
+func isPow2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// in place radix-2 Cooley-Tukey FFT. len(a) must be a power of 2.
+func fft(a []complex128) {
+	n := len(a)
+
+	// bit reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, -2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := a[start+k], a[start+k+size/2]*wk
+				a[start+k] = even + odd
+				a[start+k+size/2] = even - odd
+				wk *= w
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package analysis
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/quillaja/goutil/rand"
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+// samples f over a size x size map covering [0,extent] in x and y.
+func sampleMap(f func(x, y float64) float64, extent float64, size int) *noisemap.Map {
+	r := noisemap.Rect{MaxX: extent, MaxY: extent}
+	return noisemap.SampleSerial(rand.Noise2DFunc(f), r, size, size)
+}
+
+func TestFFT(t *testing.T) {
+	// compare to a naive DFT
+	a := make([]complex128, 16)
+	for i := range a {
+		a[i] = complex(math.Sin(float64(i*i)), math.Cos(float64(3*i)))
+	}
+	want := make([]complex128, len(a))
+	for k := range want {
+		for n, v := range a {
+			want[k] += v * cmplx.Rect(1, -2*math.Pi*float64(k*n)/float64(len(a)))
+		}
+	}
+	fft(a)
+	for k := range a {
+		if cmplx.Abs(a[k]-want[k]) > 1e-9 {
+			t.Errorf("a[%d] = %v, want %v", k, a[k], want[k])
+		}
+	}
+}
+
+func TestPowerSpectrum_Sine(t *testing.T) {
+	// 8 cycles across the map in x
+	m := sampleMap(func(x, y float64) float64 { return math.Sin(2 * math.Pi * x / 4) }, 32, 64)
+	s := PowerSpectrum(m)
+
+	if v := Describe(m.Values).Variance; !nearlyEqual(s.Total(), v, 1e-9) {
+		t.Errorf("total power %g, want variance %g", s.Total(), v)
+	}
+	if p := s.At(8, 0) + s.At(-8, 0); !nearlyEqual(p, s.Total(), 1e-9) {
+		t.Errorf("power at 8 cycles %g, want %g", p, s.Total())
+	}
+
+	radial := s.Radial()
+	for i, p := range radial {
+		if i != 8 && p > 1e-12 {
+			t.Errorf("radial[%d] = %g, want 0", i, p)
+		}
+	}
+	if c := s.Centroid(); !nearlyEqual(c, 8, 1e-9) {
+		t.Errorf("centroid %g, want 8", c)
+	}
+	if d := s.Directional(4); !nearlyEqual(d[0], 1, 1e-9) {
+		t.Errorf("directional %v, want all power in sector 0", d)
+	}
+}
+
+func TestPowerSpectrum_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	PowerSpectrum(noisemap.New(10, 8))
+}
+
+func TestAnisotropy(t *testing.T) {
+	p := rand.NewPerlin(1)
+	tests := []struct {
+		name     string
+		noise    func(x, y float64) float64
+		low, top float64
+	}{
+		{"white", func(x, y float64) float64 { return rand.Float64NM(-1, 1) }, 0, 0.2},
+		{"perlin", func(x, y float64) float64 { return p.Noise(x, y, 0.37) }, 0, 1},
+		{"perlin xz", func(x, y float64) float64 { return p.Noise(x, 0.37, y) }, 0, 1},
+		{"perlin yz", func(x, y float64) float64 { return p.Noise(0.37, x, y) }, 0, 1},
+		{"stretched", func(x, y float64) float64 { return p.Noise(3*x, y, 0.37) }, 1.5, math.Inf(1)},
+		{"1d", func(x, y float64) float64 { return p.Noise(x, 0.37, 0.37) }, 5, math.Inf(1)},
+	}
+	rand.Seed(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := PowerSpectrum(sampleMap(tt.noise, 32, 256)).Anisotropy(8)
+			if a < tt.low || tt.top < a {
+				t.Errorf("anisotropy %g not in [%g,%g]", a, tt.low, tt.top)
+			}
+		})
+	}
+}
+
+func TestSeamError(t *testing.T) {
+	p := rand.NewPerlin(1)
+	tests := []struct {
+		name     string
+		noise    func(x, y float64) float64
+		low, top float64
+	}{
+		// independent values have no more of a jump at the seams than anywhere else
+		{"white", func(x, y float64) float64 { return rand.Float64NM(-1, 1) }, 0.9, 1.1},
+		{"perlin", func(x, y float64) float64 { return p.Noise(x, y, 0.37) }, 2, math.Inf(1)},
+	}
+	rand.Seed(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := SeamError(sampleMap(tt.noise, 32, 128))
+			if e < tt.low || tt.top < e {
+				t.Errorf("seam error %g not in [%g,%g]", e, tt.low, tt.top)
+			}
+		})
+	}
+}
+
+// guards the statistics of the perlin noise in package rand, so changes to
+// it (such as its gradients) that change its character are noticed.
+func TestAnalyze_Perlin(t *testing.T) {
+	p := rand.NewPerlin(1)
+	single := Analyze(sampleMap(func(x, y float64) float64 { return p.Noise(x, y, 0.37) }, 32, 256))
+	t.Log("\n", single)
+
+	if h := single.Histogram; h.Under != 0 || h.Over != 0 {
+		t.Errorf("%d values below and %d above [-1,1]", h.Under, h.Over)
+	}
+	if m := single.Stats.Mean; math.Abs(m) > 0.05 {
+		t.Errorf("mean %g, want about 0", m)
+	}
+	if sd := single.Stats.StdDev(); sd < 0.2 || 0.35 < sd {
+		t.Errorf("std dev %g not in [0.2,0.35]", sd)
+	}
+
+	// most of the power of one octave should be around 1 cycle per unit,
+	// which is 32 cycles over the map
+	if c := single.Centroid; c < 10 || 25 < c {
+		t.Errorf("centroid %g not in [10,25]", c)
+	}
+
+	// more octaves means finer detail
+	f := rand.NewFractal(p, rand.FBM)
+	fractal := Analyze(sampleMap(func(x, y float64) float64 { return f.Noise(x, y, 0.37) }, 32, 256))
+	if fractal.Centroid <= single.Centroid {
+		t.Errorf("fractal centroid %g <= single octave %g", fractal.Centroid, single.Centroid)
+	}
+}
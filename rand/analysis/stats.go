@@ -0,0 +1,111 @@
+// Package analysis measures the quality of noise: the distribution of its
+// values, its power spectrum, how isotropic it is, and how well it tiles.
+// The results are plain numbers and slices, so tests can assert on them to
+// catch regressions in the noise generators of package rand.
+//
+// Everything works on a noisemap.Map, which can be sampled from any noise
+// function with noisemap.Sample.
+package analysis
+
+import (
+	"fmt"
+	"math"
+)
+
+// Stats summarizes a set of values.
+type Stats struct {
+	N        int
+	Min, Max float64
+	Mean     float64
+	Variance float64 // population variance
+}
+
+// Describe computes the stats of values.
+func Describe(values []float64) Stats {
+	s := Stats{N: len(values), Min: math.Inf(1), Max: math.Inf(-1)}
+	if s.N == 0 {
+		return Stats{}
+	}
+	// Welford's algorithm
+	var m2 float64
+	for i, v := range values {
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+		d := v - s.Mean
+		s.Mean += d / float64(i+1)
+		m2 += d * (v - s.Mean)
+	}
+	s.Variance = m2 / float64(s.N)
+	return s
+}
+
+// StdDev is the standard deviation of the values.
+func (s Stats) StdDev() float64 {
+	return math.Sqrt(s.Variance)
+}
+
+// String formats the stats on one line.
+func (s Stats) String() string {
+	return fmt.Sprintf("n=%d min=%.4f max=%.4f mean=%.4f sd=%.4f", s.N, s.Min, s.Max, s.Mean, s.StdDev())
+}
+
+// Histogram counts values in equal width bins between Low and High.
+type Histogram struct {
+	Low, High float64
+	Counts    []int
+	// Under and Over count the values below Low and above High, which
+	// makes histograms useful for range checks.
+	Under, Over int
+	// NaN counts the values that are NaN, which are in no bin.
+	NaN int
+}
+
+// NewHistogram bins values into the given number of bins between low and
+// high. A value equal to high goes in the last bin. Panics if bins < 1 or
+// low isn't < high.
+func NewHistogram(values []float64, low, high float64, bins int) *Histogram {
+	if bins < 1 || !(low < high) {
+		panic(fmt.Errorf("Invalid params: %d bins over [%g,%g]", bins, low, high))
+	}
+	h := &Histogram{Low: low, High: high, Counts: make([]int, bins)}
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			h.NaN++
+		case v < low:
+			h.Under++
+		case v > high:
+			h.Over++
+		default:
+			i := int((v - low) / (high - low) * float64(bins))
+			h.Counts[min(i, bins-1)]++
+		}
+	}
+	return h
+}
+
+// Total is the number of values counted, including Under, Over and NaN.
+func (h *Histogram) Total() int {
+	t := h.Under + h.Over + h.NaN
+	for _, c := range h.Counts {
+		t += c
+	}
+	return t
+}
+
+// Fractions gets the fraction of all values in each bin.
+func (h *Histogram) Fractions() []float64 {
+	f := make([]float64, len(h.Counts))
+	if t := h.Total(); t > 0 {
+		for i, c := range h.Counts {
+			f[i] = float64(c) / float64(t)
+		}
+	}
+	return f
+}
+
+// Bin gets the range of values counted in bin i.
+func (h *Histogram) Bin(i int) (low, high float64) {
+	w := (h.High - h.Low) / float64(len(h.Counts))
+	return h.Low + float64(i)*w, h.Low + float64(i+1)*w
+}
@@ -0,0 +1,69 @@
+package analysis
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	s := Describe([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	want := Stats{N: 8, Min: 2, Max: 9, Mean: 5, Variance: 4}
+	if s != want {
+		t.Errorf("got %+v, want %+v", s, want)
+	}
+	if s.StdDev() != 2 {
+		t.Errorf("std dev %g", s.StdDev())
+	}
+	if s := Describe(nil); s != (Stats{}) {
+		t.Errorf("empty: got %+v", s)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram([]float64{-2, -1, -0.5, 0, 0.1, 0.9, 1, 1.5}, -1, 1, 4)
+	if want := []int{1, 1, 2, 2}; !reflect.DeepEqual(h.Counts, want) {
+		t.Errorf("counts %v, want %v", h.Counts, want)
+	}
+	if h.Under != 1 || h.Over != 1 || h.Total() != 8 {
+		t.Errorf("under %d, over %d, total %d", h.Under, h.Over, h.Total())
+	}
+	if f := h.Fractions(); f[2] != 0.25 {
+		t.Errorf("fractions %v", f)
+	}
+	if lo, hi := h.Bin(1); lo != -0.5 || hi != 0 {
+		t.Errorf("bin 1 is [%g,%g)", lo, hi)
+	}
+}
+
+func TestHistogram_NaN(t *testing.T) {
+	h := NewHistogram([]float64{0.5, math.NaN(), -0.5, math.NaN()}, -1, 1, 2)
+	if want := []int{1, 1}; !reflect.DeepEqual(h.Counts, want) {
+		t.Errorf("counts %v, want %v", h.Counts, want)
+	}
+	if h.NaN != 2 || h.Under != 0 || h.Over != 0 || h.Total() != 4 {
+		t.Errorf("NaN %d, under %d, over %d, total %d", h.NaN, h.Under, h.Over, h.Total())
+	}
+
+	// a report on a map with a NaN in it shows the NaN
+	m := sampleMap(func(x, y float64) float64 { return 0.5 * math.Sin(x) }, 4, 16)
+	m.Values[5] = math.NaN()
+	if r := Analyze(m); !strings.Contains(r.String(), "1 NaN") {
+		t.Errorf("report doesn't show the NaN:\n%s", r)
+	}
+}
+
+func TestHistogram_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewHistogram(nil, 1, 1, 10)
+}
+
+// checks that a and b are within tol of each other.
+func nearlyEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
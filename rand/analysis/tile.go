@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/rand/noisemap"
+)
+
+// SeamError measures how visible the seams are when m is tiled. For each
+// direction it compares the mean absolute difference across the seam (between
+// the last and first columns, or rows) to the mean absolute difference
+// between neighbors inside the map, and returns the worse of the two ratios.
+// It's about 1 for noise that tiles seamlessly and larger the more visible
+// the seams are.
+func SeamError(m *noisemap.Map) float64 {
+	var seam, inner [2]float64 // x then y
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			v := m.At(x, y)
+			dx := math.Abs(m.At((x+1)%m.Width, y) - v)
+			dy := math.Abs(m.At(x, (y+1)%m.Height) - v)
+			if x == m.Width-1 {
+				seam[0] += dx / float64(m.Height)
+			} else {
+				inner[0] += dx / float64((m.Width-1)*m.Height)
+			}
+			if y == m.Height-1 {
+				seam[1] += dy / float64(m.Width)
+			} else {
+				inner[1] += dy / float64(m.Width*(m.Height-1))
+			}
+		}
+	}
+
+	worst := 0.0
+	for i := range seam {
+		switch {
+		case inner[i] > 0:
+			worst = math.Max(worst, seam[i]/inner[i])
+		case seam[i] > 0:
+			return math.Inf(1) // constant, except for the seam
+		}
+	}
+	return worst
+}
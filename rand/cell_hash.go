@@ -0,0 +1,67 @@
+package rand
+
+import "math"
+
+// mixes the bits of h. it's the finalizer of SplitMix64.
+// See: https://prng.di.unimi.it/splitmix64.c
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xBF58476D1CE4E5B9
+	h ^= h >> 27
+	h *= 0x94D049BB133111EB
+	h ^= h >> 31
+	return h
+}
+
+// hashes the integer coordinates of a cell together with a seed.
+func hashCell(seed uint64, coords ...int64) uint64 {
+	h := mix64(seed + 0x9E3779B97F4A7C15)
+	for _, c := range coords {
+		h = mix64(h ^ uint64(c)*0x9E3779B97F4A7C15)
+	}
+	return h
+}
+
+// makes a 64 bit key from a permutation table, so that a seed used to make
+// the table can also be used to seed hashCell().
+func permKey(p *[512]int) uint64 {
+	var h uint64
+	for _, v := range p[:256] {
+		h = mix64(h ^ uint64(v))
+	}
+	return h
+}
+
+// cellRNG is a small PRNG (SplitMix64) used to place feature points in a
+// cell. Seeding it with hashCell() gives each cell its own uncorrelated
+// stream of numbers.
+type cellRNG struct {
+	src SplitMix64
+}
+
+// creates a cellRNG for the cell at coords.
+func newCellRNG(key uint64, coords ...int64) cellRNG {
+	return cellRNG{src: SplitMix64{state: hashCell(key, coords...)}}
+}
+
+// gets a random float64 in [0,1).
+func (r *cellRNG) float64() float64 {
+	return float64(r.src.Uint64()>>11) / (1 << 53)
+}
+
+// gets a poisson distributed count with mean lambda. see Rand.Poisson.
+func (r *cellRNG) poisson(lambda float64) int {
+	if lambda >= 30 {
+		// copy the state so only this rare case allocates
+		src := r.src
+		k := New(&src).Poisson(lambda)
+		r.src = src
+		return k
+	}
+	limit, prod, k := math.Exp(-lambda), r.float64(), 0
+	for prod > limit {
+		prod *= r.float64()
+		k++
+	}
+	return k
+}
@@ -51,7 +51,8 @@ func (p *point3) Location() []float64 {
 // CellNoiseSlow creates a function which gives 2D cell noise. Lambda and max determine
 // the number of feature points in a given unit cube, which lambda is the average
 // number per cell and max is the maximum number per cell. DistanceMetric dist
-// provides the notion of 'distance'.
+// provides the notion of 'distance'. The function is seeded by the package's
+// permutation table (see FillPermutation) at the time it's created.
 func CellNoiseSlow(lambda, max int, dist data.DistanceMetric) func(x, y float64) float64 {
 	cdf := make([]float64, max+1, max+1)
 	for k, t := 0, 0.0; k <= max; k++ {
@@ -60,6 +61,7 @@ func CellNoiseSlow(lambda, max int, dist data.DistanceMetric) func(x, y float64)
 		cdf[k] = t
 	}
 
+	key := permKey(p)
 	return func(x, y float64) float64 {
 		nearest := math.Inf(0)
 
@@ -70,18 +72,10 @@ func CellNoiseSlow(lambda, max int, dist data.DistanceMetric) func(x, y float64)
 				xc, yc := math.Floor(x)+float64(c), math.Floor(y)+float64(r)
 
 				// 2. generate a reproducible RNG for the cube (create seed by hashing)
-				seed := p[p[int(xc)&0xFF]+int(yc)&0xFF]
+				rng := newCellRNG(key, int64(xc), int64(yc))
 
 				// 3. determine how many feature points are in the cube
-				npts := max
-				selection := float64(p[seed]) / 256
-				for i := 0; i < len(cdf); i++ {
-					if selection <= cdf[i] {
-						npts = i
-						break
-					}
-				}
-				npts = num.ClampInt(npts, 1, max)
+				npts := pointsInCell(cdf, max, rng.float64())
 
 				// 4. place random feature points in the cube and
 				// 5. keep track of the closest one
@@ -89,8 +83,8 @@ func CellNoiseSlow(lambda, max int, dist data.DistanceMetric) func(x, y float64)
 					d := dist(
 						[]float64{x, y},
 						[]float64{
-							xc + float64(p[seed+(npts*2)])/256,
-							yc + float64(p[seed+(npts*2-1)])/256})
+							xc + rng.float64(),
+							yc + rng.float64()})
 					if d < nearest {
 						nearest = d
 					}
@@ -112,6 +106,7 @@ type CellNoise2D struct {
 	maxPtsPerCell  int
 	dist           data.DistanceMetric
 	perm           *[512]int
+	seed           int64
 }
 
 // NewCellNoise2D creates a pointer to a new CellNoise2D struct.
@@ -135,6 +130,7 @@ func NewCellNoise2D(seed int64, lambda, maxPtsPerCell int, dist data.DistanceMet
 		maxPtsPerCell: maxPtsPerCell,
 		dist:          dist,
 		perm:          MakePermutation(seed),
+		seed:          seed,
 	}
 
 	conf.tree.Build(MakeNoisePoints2D(
@@ -146,6 +142,24 @@ func NewCellNoise2D(seed int64, lambda, maxPtsPerCell int, dist data.DistanceMet
 
 // Noise gets a noise value at the given point (x, y).
 func (conf *CellNoise2D) Noise(x, y float64) float64 {
+	conf.expand(x, y)
+
+	// 6??? return dist to nearest neighbor (or Nth nearest, or points themselves...or?)
+	// see NoiseN() for the others.
+	nearest := conf.tree.NearestNeighbor(conf.dist, x, y) // could but should not return nil
+	return num.ClampFloat(conf.dist([]float64{x, y}, nearest.Location()), 0, 1)
+}
+
+// NoiseN gets the distances to the n nearest feature points to (x, y), as well
+// as the location and ID of the nearest one. Unlike Noise, the distances
+// are not clamped.
+func (conf *CellNoise2D) NoiseN(x, y float64, n int) CellResult {
+	conf.expand(x, y)
+	return makeCellResult(conf.seed, conf.dist, conf.tree.NearestNeighbors(conf.dist, n, x, y), x, y)
+}
+
+// makes sure feature points have been generated for the cells around (x, y).
+func (conf *CellNoise2D) expand(x, y float64) {
 	// if x and y go past already calculated ranges of cells,
 	// new cells need to be calculated and added.
 	rebuild := false
@@ -179,16 +193,15 @@ func (conf *CellNoise2D) Noise(x, y float64) float64 {
 		// fmt.Println(" num points", tree.Len(), "rebuild took (ms):", time.Since(start).Seconds()*1000)
 		// fmt.Println(" theoretical size of points (KB):", tree.Len()*16/1024) // 8bytes per float64 * 2 per point * points / bytes/kb
 	}
-
-	// 6??? return dist to nearest neighbor (or Nth nearest, or points themselves...or?)
-	nearest := conf.tree.NearestNeighbor(conf.dist, x, y) // could but should not return nil
-	return num.ClampFloat(conf.dist([]float64{x, y}, nearest.Location()), 0, 1)
-
 }
 
 // MakeNoisePoints2D generates all the points for all the cells given the parameters.
+// The permutation table p only serves as a seed; the points in each cell are
+// placed by a small PRNG seeded by hashing the cell's coordinates, so there's
+// no limit on maxPtsPerCell.
 func MakeNoisePoints2D(xrange, yrange [2]int, maxPtsPerCell int, cdf []float64, p *[512]int) []data.Interface {
 	points := make([]data.Interface, 0, 500) // TODO: fix arbitrary size
+	key := permKey(p)
 	// for the cell and 8 cells in its neighborhood
 	for yc := yrange[0] - 1; yc <= yrange[1]+1; yc++ {
 		for xc := xrange[0] - 1; xc <= xrange[1]+1; xc++ {
@@ -196,27 +209,16 @@ func MakeNoisePoints2D(xrange, yrange [2]int, maxPtsPerCell int, cdf []float64,
 			// xc, yc := math.Floor(x)+float64(c), math.Floor(y)+float64(r)
 
 			// 2. generate a reproducible RNG for the cube (create seed by hashing)
-			seed := p[p[xc&0xFF]+yc&0xFF]
-			// if seed < 0 {
-			// 	seed = -seed
-			// }
+			rng := newCellRNG(key, int64(xc), int64(yc))
 
 			// 3. determine how many feature points are in the cube
-			npts := maxPtsPerCell
-			selection := float64(p[seed]) / 256
-			for i, cump := range cdf {
-				if selection <= cump {
-					npts = i
-					break
-				}
-			}
-			npts = num.ClampInt(npts, 1, maxPtsPerCell)
+			npts := pointsInCell(cdf, maxPtsPerCell, rng.float64())
 
 			// 4. place random feature points in the cube
 			for ; npts > 0; npts-- {
 				points = append(points, &point{
-					float64(xc) + float64(p[seed+(npts*2)])/256,
-					float64(yc) + float64(p[seed+(npts*2-1)])/256,
+					float64(xc) + rng.float64(),
+					float64(yc) + rng.float64(),
 				})
 			}
 		}
@@ -226,9 +228,11 @@ func MakeNoisePoints2D(xrange, yrange [2]int, maxPtsPerCell int, cdf []float64,
 }
 
 // MakeNoisePoints3D generates all the points for all the cells given the parameters.
+// See MakeNoisePoints2D.
 func MakeNoisePoints3D(xrange, yrange, zrange [2]int, maxPtsPerCell int, cdf []float64, p *[512]int) []data.Interface {
 	// totalCells := (maxX / cellSize * maxY / cellSize * maxZ / cellSize) // TODO: figure out better way to determine this
 	points := make([]data.Interface, 0, 5000) //int(totalCells))
+	key := permKey(p)
 
 	// for the cell and 8 cells in its neighborhood
 	for zc := zrange[0] - 1; zc <= zrange[1]+1; zc++ {
@@ -238,31 +242,17 @@ func MakeNoisePoints3D(xrange, yrange, zrange [2]int, maxPtsPerCell int, cdf []f
 				// xc, yc := math.Floor(x)+float64(c), math.Floor(y)+float64(r)
 
 				// 2. generate a reproducible RNG for the cube (create seed by hashing)
-				seed := p[p[p[xc&0xFF]+yc&0xFF]+zc&0xFF]
-				// seed := hash(hash(xc, yc), zc) % 256
-				// if seed < 0 {
-				// 	seed = -seed
-				// }
+				rng := newCellRNG(key, int64(xc), int64(yc), int64(zc))
 
 				// 3. determine how many feature points are in the cube
-				npts := maxPtsPerCell
-				selection := float64(p[seed]) / 256
-				for i, cump := range cdf {
-					if selection <= cump {
-						npts = i
-						break
-					}
-				}
-				npts = num.ClampInt(npts, 1, maxPtsPerCell)
+				npts := pointsInCell(cdf, maxPtsPerCell, rng.float64())
 
 				// 4. place random feature points in the cube
-				// can't multiply offset with 3 because the p-array is
-				// only 512 long, and seed is [0,255]
 				for ; npts > 0; npts-- {
 					points = append(points, &point3{
-						float64(xc) + float64(p[seed+(npts*2)])/256,
-						float64(yc) + float64(p[seed+(npts*2-1)])/256,
-						float64(zc) + float64(p[seed+(npts*2-2)])/256,
+						float64(xc) + rng.float64(),
+						float64(yc) + rng.float64(),
+						float64(zc) + rng.float64(),
 					})
 				}
 			}
@@ -280,6 +270,7 @@ type CellNoise3D struct {
 	maxPtsPerCell          int
 	dist                   data.DistanceMetric
 	perm                   *[512]int
+	seed                   int64
 }
 
 // NewCellNoise3D creates a pointer to a new CellNoise3D struct.
@@ -304,6 +295,7 @@ func NewCellNoise3D(seed int64, lambda, maxPtsPerCell int, dist data.DistanceMet
 		maxPtsPerCell: maxPtsPerCell,
 		dist:          dist,
 		perm:          MakePermutation(seed),
+		seed:          seed,
 	}
 
 	conf.tree.Build(MakeNoisePoints3D(
@@ -315,6 +307,24 @@ func NewCellNoise3D(seed int64, lambda, maxPtsPerCell int, dist data.DistanceMet
 
 // Noise generates a noise value at the (x,y,z) location.
 func (conf *CellNoise3D) Noise(x, y, z float64) float64 {
+	conf.expand(x, y, z)
+
+	// 6??? return nearest neighbor, Nth nearest, or their distances or?
+	// see NoiseN() for the others.
+	nearest := conf.tree.NearestNeighbor(conf.dist, x, y, z) // could but should not return nil
+	return num.ClampFloat(conf.dist([]float64{x, y, z}, nearest.Location()), 0, 1)
+}
+
+// NoiseN gets the distances to the n nearest feature points to (x, y, z), as
+// well as the location and ID of the nearest one. Unlike Noise, the
+// distances are not clamped.
+func (conf *CellNoise3D) NoiseN(x, y, z float64, n int) CellResult {
+	conf.expand(x, y, z)
+	return makeCellResult(conf.seed, conf.dist, conf.tree.NearestNeighbors(conf.dist, n, x, y, z), x, y, z)
+}
+
+// makes sure feature points have been generated for the cells around (x, y, z).
+func (conf *CellNoise3D) expand(x, y, z float64) {
 	rebuild := false
 	zrebuild := false
 	xc, yc, zc := int(x), int(y), int(z)
@@ -360,10 +370,6 @@ func (conf *CellNoise3D) Noise(x, y, z float64) float64 {
 		// fmt.Println(" num points", conf.tree.Len(), "rebuild took (ms):", time.Since(start).Seconds()*1000)
 		// fmt.Println(" theoretical size of points (KB):", conf.tree.Len()*24/1024) // 8bytes per float64 * 3 per point * points / bytes/kb
 	}
-
-	// 6??? return nearest neighbor, Nth nearest, or their distances or?
-	nearest := conf.tree.NearestNeighbor(conf.dist, x, y, z) // could but should not return nil
-	return num.ClampFloat(conf.dist([]float64{x, y, z}, nearest.Location()), 0, 1)
 }
 
 // DEPRECATED
@@ -1,6 +1,7 @@
 package rand
 
 import (
+	"math"
 	"testing"
 
 	"github.com/quillaja/goutil/data"
@@ -35,3 +36,101 @@ func BenchmarkCellNoise3D(b *testing.B) {
 		noise.Noise(offset, offset, offset)
 	}
 }
+
+// chi-square statistic for observed counts against a uniform expectation.
+func chiSquareUniform(counts []int) float64 {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	expected := float64(total) / float64(len(counts))
+	chi := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return chi
+}
+
+// makes a cdf that always puts max points in a cell.
+func alwaysMax(max int) []float64 {
+	cdf := make([]float64, max+1)
+	cdf[max] = 1
+	return cdf
+}
+
+// chi-square critical value for 63 degrees of freedom at p = 0.001
+const chiSquare63 = 103.4
+
+func TestMakeNoisePoints2D_Uniform(t *testing.T) {
+	// more points per cell than the old 512 entry permutation allowed
+	const maxPts = 300
+	pts := MakeNoisePoints2D([2]int{0, 10}, [2]int{0, 10}, maxPts, alwaysMax(maxPts), MakePermutation(1))
+	if len(pts) != 13*13*maxPts {
+		t.Fatalf("got %d points", len(pts))
+	}
+
+	// 8x8 bins over the fractional position inside the cell
+	counts := make([]int, 64)
+	var sx, sy, sxy float64
+	for _, p := range pts {
+		loc := p.Location()
+		fx, fy := loc[0]-math.Floor(loc[0]), loc[1]-math.Floor(loc[1])
+		counts[int(fy*8)*8+int(fx*8)]++
+		sx, sy, sxy = sx+fx, sy+fy, sxy+fx*fy
+	}
+	if chi := chiSquareUniform(counts); chi > chiSquare63 {
+		t.Errorf("points not uniform in cell: chi-square = %g", chi)
+	}
+	n := float64(len(pts))
+	if cov := sxy/n - (sx/n)*(sy/n); math.Abs(cov) > 0.005 {
+		t.Errorf("x and y correlated: covariance = %g", cov)
+	}
+}
+
+func TestMakeNoisePoints3D_Uniform(t *testing.T) {
+	const maxPts = 200
+	pts := MakeNoisePoints3D([2]int{0, 5}, [2]int{0, 5}, [2]int{0, 5}, maxPts, alwaysMax(maxPts), MakePermutation(1))
+	if len(pts) != 8*8*8*maxPts {
+		t.Fatalf("got %d points", len(pts))
+	}
+
+	// 4x4x4 bins over the fractional position inside the cell
+	counts := make([]int, 64)
+	var s [3]float64
+	var syz, sxz float64
+	for _, p := range pts {
+		loc := p.Location()
+		var f [3]float64
+		for i := range f {
+			f[i] = loc[i] - math.Floor(loc[i])
+			s[i] += f[i]
+		}
+		counts[int(f[2]*4)*16+int(f[1]*4)*4+int(f[0]*4)]++
+		syz += f[1] * f[2]
+		sxz += f[0] * f[2]
+	}
+	if chi := chiSquareUniform(counts); chi > chiSquare63 {
+		t.Errorf("points not uniform in cell: chi-square = %g", chi)
+	}
+	n := float64(len(pts))
+	if cov := syz/n - (s[1]/n)*(s[2]/n); math.Abs(cov) > 0.005 {
+		t.Errorf("y and z correlated: covariance = %g", cov)
+	}
+	if cov := sxz/n - (s[0]/n)*(s[2]/n); math.Abs(cov) > 0.005 {
+		t.Errorf("x and z correlated: covariance = %g", cov)
+	}
+}
+
+func TestMakeNoisePoints2D_Deterministic(t *testing.T) {
+	a := MakeNoisePoints2D([2]int{-5, 5}, [2]int{-5, 5}, 5, poissonCDF(2, 5), MakePermutation(7))
+	b := MakeNoisePoints2D([2]int{-5, 5}, [2]int{-5, 5}, 5, poissonCDF(2, 5), MakePermutation(7))
+	if len(a) != len(b) {
+		t.Fatalf("%d != %d points", len(a), len(b))
+	}
+	for i := range a {
+		if *a[i].(*point) != *b[i].(*point) {
+			t.Errorf("point %d: %v != %v", i, a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package rand
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/data"
+)
+
+// CellResult holds the full set of cell noise (Worley noise) features
+// at a location.
+type CellResult struct {
+	// F holds the distances to the nearest feature points in ascending order,
+	// so F[0] is "F1", F[1] is "F2", etc. It may be shorter than requested if
+	// too few feature points were found.
+	F []float64
+	// Nearest is the location of the nearest feature point, or nil if none
+	// was found.
+	Nearest []float64
+	// ID is a hash of the nearest feature point's location. It's the same
+	// everywhere that point is nearest and differs between feature points,
+	// even ones in the same lattice cell, so it can be used to give each
+	// voronoi region a flat color. It's 0 if no feature point was found.
+	ID uint32
+}
+
+// Fn gets the distance to the nth nearest feature point, with n starting at 1.
+// Returns +Inf if there is no such point.
+func (r CellResult) Fn(n int) float64 {
+	if n < 1 || n > len(r.F) {
+		return math.Inf(1)
+	}
+	return r.F[n-1]
+}
+
+// F1 gets the distance to the nearest feature point.
+func (r CellResult) F1() float64 { return r.Fn(1) }
+
+// F2 gets the distance to the 2nd nearest feature point.
+func (r CellResult) F2() float64 { return r.Fn(2) }
+
+// F2MinusF1 is F2 - F1, which is 0 along the edges between voronoi regions
+// and gives a cracked, cellular look.
+func (r CellResult) F2MinusF1() float64 { return r.F2() - r.F1() }
+
+// F1TimesF2 is F1 * F2.
+func (r CellResult) F1TimesF2() float64 { return r.F1() * r.F2() }
+
+// makes a CellResult for the search point pt from the nearest neighbors found.
+func makeCellResult(seed int64, dist data.DistanceMetric, found []data.Interface, pt ...float64) CellResult {
+	r := CellResult{F: make([]float64, len(found))}
+	for i, f := range found {
+		r.F[i] = dist(pt, f.Location())
+	}
+	if len(found) > 0 {
+		loc := found[0].Location()
+		r.Nearest = append([]float64(nil), loc...)
+		r.ID = featureID(seed, loc...)
+	}
+	return r
+}
+
+// gets the CellResult ID of the feature point at loc. it hashes the exact
+// coordinates rather than the lattice cell, since a cell usually holds
+// several feature points.
+func featureID(seed int64, loc ...float64) uint32 {
+	var bits [3]int64
+	for i := range loc {
+		bits[i] = int64(math.Float64bits(loc[i]))
+	}
+	return uint32(hashCell(uint64(seed), bits[:len(loc)]...))
+}
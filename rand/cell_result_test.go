@@ -0,0 +1,108 @@
+package rand
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/quillaja/goutil/data"
+)
+
+func TestCellNoise2D_NoiseN(t *testing.T) {
+	noise := NewCellNoise2D(1, 2, 5, data.Euclidean)
+	for i := 0; i < 500; i++ {
+		x, y := float64(i%25)*0.11, float64(i/25)*0.13
+		r := noise.NoiseN(x, y, 3)
+		if len(r.F) != 3 {
+			t.Fatalf("got %d distances", len(r.F))
+		}
+		if !sort.Float64sAreSorted(r.F) {
+			t.Errorf("distances not sorted: %v", r.F)
+		}
+		if got := noise.Noise(x, y); got != math.Min(r.F1(), 1) {
+			t.Errorf("Noise = %g, F1 = %g", got, r.F1())
+		}
+		if d := data.Euclidean([]float64{x, y}, r.Nearest); d != r.F1() {
+			t.Errorf("distance to nearest %g != F1 %g", d, r.F1())
+		}
+		if r.F2MinusF1() < 0 {
+			t.Errorf("F2-F1 < 0: %g", r.F2MinusF1())
+		}
+		if r.Fn(4) != math.Inf(1) {
+			t.Errorf("Fn(4) = %g, want +Inf", r.Fn(4))
+		}
+	}
+}
+
+func TestCellNoise2D_ID(t *testing.T) {
+	// points with the same nearest feature should have the same ID
+	noise := NewCellNoise2D(1, 2, 5, data.Euclidean)
+	ids := map[[2]float64]uint32{}
+	for i := 0; i < 2500; i++ {
+		x, y := float64(i%50)*0.05, float64(i/50)*0.05
+		r := noise.NoiseN(x, y, 1)
+		key := [2]float64{r.Nearest[0], r.Nearest[1]}
+		if id, ok := ids[key]; ok && id != r.ID {
+			t.Fatalf("feature %v has IDs %d and %d", key, id, r.ID)
+		}
+		ids[key] = r.ID
+	}
+
+	// and a new generator with the same seed should agree
+	again := NewCellNoise2D(1, 2, 5, data.Euclidean)
+	for key, id := range ids {
+		if r := again.NoiseN(key[0], key[1], 1); r.ID != id {
+			t.Errorf("ID at %v is %d, was %d", key, r.ID, id)
+		}
+	}
+}
+
+func TestCellNoise2D_IDSameCell(t *testing.T) {
+	// different feature points in the same lattice cell get different IDs
+	noise := NewCellNoise2D(1, 2, 5, data.Euclidean)
+	cells := map[[2]float64]map[[2]float64]uint32{}
+	for i := 0; i < 2500; i++ {
+		x, y := float64(i%50)*0.05, float64(i/50)*0.05
+		r := noise.NoiseN(x, y, 1)
+		cell := [2]float64{math.Floor(r.Nearest[0]), math.Floor(r.Nearest[1])}
+		if cells[cell] == nil {
+			cells[cell] = map[[2]float64]uint32{}
+		}
+		cells[cell][[2]float64{r.Nearest[0], r.Nearest[1]}] = r.ID
+	}
+
+	shared := 0
+	for cell, features := range cells {
+		if len(features) < 2 {
+			continue
+		}
+		shared++
+		ids := map[uint32]bool{}
+		for _, id := range features {
+			ids[id] = true
+		}
+		if len(ids) != len(features) {
+			t.Errorf("cell %v: %d feature points have only %d IDs", cell, len(features), len(ids))
+		}
+	}
+	if shared == 0 {
+		t.Fatal("no lattice cell with more than one feature point was found")
+	}
+}
+
+func TestCellNoise3D_NoiseN(t *testing.T) {
+	noise := NewCellNoise3D(1, 2, 5, data.Euclidean)
+	for i := 0; i < 500; i++ {
+		x, y, z := float64(i%25)*0.11, float64(i/25)*0.13, 0.5
+		r := noise.NoiseN(x, y, z, 2)
+		if len(r.F) != 2 || len(r.Nearest) != 3 {
+			t.Fatalf("got %d distances, nearest %v", len(r.F), r.Nearest)
+		}
+		if got := noise.Noise(x, y, z); got != math.Min(r.F1(), 1) {
+			t.Errorf("Noise = %g, F1 = %g", got, r.F1())
+		}
+		if r.F1TimesF2() < r.F1()*r.F1() {
+			t.Errorf("F1*F2 %g < F1*F1", r.F1TimesF2())
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normal gets a normally distributed number with the given mean and
+// standard deviation, using the Marsaglia polar method.
+func (r *Rand) Normal(mean, stddev float64) float64 {
+	for {
+		u, v := 2*r.Float64()-1, 2*r.Float64()-1
+		s := u*u + v*v
+		if 0 < s && s < 1 {
+			return mean + stddev*u*math.Sqrt(-2*math.Log(s)/s)
+		}
+	}
+}
+
+// LogNormal gets a number whose natural log is normally distributed
+// with the given mean and standard deviation.
+func (r *Rand) LogNormal(mean, stddev float64) float64 {
+	return math.Exp(r.Normal(mean, stddev))
+}
+
+// Exponential gets an exponentially distributed number with the given rate
+// (lambda), so the mean is 1/rate. Panics if rate <= 0.
+func (r *Rand) Exponential(rate float64) float64 {
+	if rate <= 0 {
+		panic(fmt.Errorf("Invalid params: rate %g not > 0", rate))
+	}
+	// 1-Float64() is in (0,1], avoiding log(0)
+	return -math.Log(1-r.Float64()) / rate
+}
+
+// Poisson gets the number of times an event happens when it is expected
+// to happen lambda times. Panics if lambda is negative, infinite or NaN.
+func (r *Rand) Poisson(lambda float64) int {
+	if !(lambda >= 0) || math.IsInf(lambda, 1) {
+		panic(fmt.Errorf("Invalid params: lambda %g not finite and >= 0", lambda))
+	}
+	if lambda <= 30 {
+		// Knuth: multiply uniforms until the product drops below e^-lambda
+		limit, prod, k := math.Exp(-lambda), r.Float64(), 0
+		for prod > limit {
+			prod *= r.Float64()
+			k++
+		}
+		return k
+	}
+	// for large lambda, count the arrivals of a poisson process in
+	// chunks, each sampled as a gamma distributed waiting time.
+	// See: Knuth, TAOCP vol 2, 3.4.1
+	k := 0
+	for lambda > 30 {
+		m := int(lambda * 7 / 8)
+		x := r.Gamma(float64(m), 1)
+		if x >= lambda {
+			return k + r.Binomial(m-1, lambda/x)
+		}
+		k += m
+		lambda -= x
+	}
+	return k + r.Poisson(lambda)
+}
+
+// Binomial gets the number of successes in n trials when each succeeds with
+// probability p. Panics if n < 0 or p is not in [0,1].
+func (r *Rand) Binomial(n int, p float64) int {
+	if n < 0 || p < 0 || 1 < p {
+		panic(fmt.Errorf("Invalid params: n=%d, p=%g", n, p))
+	}
+	if n < 40 {
+		k := 0
+		for i := 0; i < n; i++ {
+			if r.Float64() < p {
+				k++
+			}
+		}
+		return k
+	}
+	// for large n, use the beta distributed order statistic to split the
+	// trials in two, then recurse on the half containing the answer.
+	// See: Knuth, TAOCP vol 2, 3.4.1
+	a := 1 + n/2
+	b := n + 1 - a
+	x := r.Beta(float64(a), float64(b))
+	if x >= p {
+		return r.Binomial(a-1, p/x)
+	}
+	return a + r.Binomial(b-1, (p-x)/(1-x))
+}
+
+// Gamma gets a gamma distributed number with the given shape (k) and scale
+// (theta), using the method of Marsaglia and Tsang. Panics if shape or
+// scale are <= 0.
+//
+// See: https://dl.acm.org/doi/10.1145/358407.358414
+func (r *Rand) Gamma(shape, scale float64) float64 {
+	if shape <= 0 || scale <= 0 {
+		panic(fmt.Errorf("Invalid params: shape %g and scale %g must be > 0", shape, scale))
+	}
+	if shape < 1 {
+		// boost to shape+1, then scale back with a uniform
+		u := 1 - r.Float64() // (0,1]
+		return r.Gamma(shape+1, scale) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.Normal(0, 1)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v * scale
+		}
+	}
+}
+
+// Beta gets a beta distributed number in [0,1] with the shape parameters
+// alpha and beta. Panics if either is <= 0.
+func (r *Rand) Beta(alpha, beta float64) float64 {
+	x := r.Gamma(alpha, 1)
+	y := r.Gamma(beta, 1)
+	return x / (x + y)
+}
+
+// Triangular gets a number in [low,high] from the triangular distribution
+// whose peak is at mode. Panics unless low <= mode <= high and low < high.
+func (r *Rand) Triangular(low, mode, high float64) float64 {
+	if !(low <= mode && mode <= high && low < high) {
+		panic(fmt.Errorf("Invalid params: %g <= %g <= %g", low, mode, high))
+	}
+	u := r.Float64()
+	f := (mode - low) / (high - low)
+	if u < f {
+		return low + math.Sqrt(u*(high-low)*(mode-low))
+	}
+	return high - math.Sqrt((1-u)*(high-low)*(high-mode))
+}
+
+// Alias samples a discrete distribution in constant time using
+// Vose's alias method.
+//
+// See: https://www.keithschwarz.com/darts-dice-coins/
+type Alias struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAlias creates an Alias for the distribution where the probability of
+// index i is proportional to weights[i]. Panics if there are no weights,
+// if any are negative, or if they're all 0.
+func NewAlias(weights []float64) *Alias {
+	n := len(weights)
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic(fmt.Errorf("Invalid params: negative weight %g", w))
+		}
+		total += w
+	}
+	if total <= 0 {
+		panic(fmt.Errorf("Invalid params: weights sum to %g", total))
+	}
+
+	a := &Alias{prob: make([]float64, n), alias: make([]int, n)}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		a.prob[s] = scaled[s]
+		a.alias[s] = l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// whatever is left is 1, give or take rounding error
+	for _, i := range append(small, large...) {
+		a.prob[i] = 1
+	}
+
+	return a
+}
+
+// NewAliasFromCDF creates an Alias from a cumulative distribution, such
+// as the one used by cell noise to pick the number of points in a cell.
+func NewAliasFromCDF(cdf []float64) *Alias {
+	weights := make([]float64, len(cdf))
+	prev := 0.0
+	for i, c := range cdf {
+		weights[i] = math.Max(c-prev, 0)
+		prev = c
+	}
+	return NewAlias(weights)
+}
+
+// Sample picks a random index using r.
+func (a *Alias) Sample(r *Rand) int {
+	i := r.Intn(len(a.prob))
+	if r.Float64() < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
@@ -0,0 +1,229 @@
+package rand
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+const distN = 20000 // samples per goodness-of-fit test
+
+// Kolmogorov-Smirnov statistic of the samples against the cdf.
+func ksStatistic(samples []float64, cdf func(float64) float64) float64 {
+	sort.Float64s(samples)
+	n := float64(len(samples))
+	d := 0.0
+	for i, x := range samples {
+		f := cdf(x)
+		d = math.Max(d, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+	}
+	return d
+}
+
+// KS critical value for n samples at p = 0.001
+func ksCritical(n int) float64 {
+	return 1.95 / math.Sqrt(float64(n))
+}
+
+// approximate chi-square critical value for p = 0.001 (Wilson-Hilferty)
+func chiSquareCritical(dof int) float64 {
+	k := float64(dof)
+	a := 2 / (9 * k)
+	return k * math.Pow(1-a+3.09*math.Sqrt(a), 3)
+}
+
+// chi-square statistic of integer samples against the pmf. values whose
+// expected count is under 5 are lumped together.
+func chiSquarePMF(samples []int, pmf func(int) float64) (chi float64, dof int) {
+	counts := map[int]int{}
+	max := 0
+	for _, s := range samples {
+		counts[s]++
+		if s > max {
+			max = s
+		}
+	}
+	n := float64(len(samples))
+	var lumpObs, lumpExp float64
+	for k := 0; k <= max+10; k++ {
+		exp := pmf(k) * n
+		if exp < 5 {
+			lumpObs += float64(counts[k])
+			lumpExp += exp
+			continue
+		}
+		d := float64(counts[k]) - exp
+		chi += d * d / exp
+		dof++
+	}
+	if lumpExp > 0 {
+		d := lumpObs - lumpExp
+		chi += d * d / lumpExp
+		dof++
+	}
+	return chi, dof - 1
+}
+
+func TestRand_ContinuousDistributions(t *testing.T) {
+	gamma3 := func(x float64) float64 { // shape 3, scale 2
+		x /= 2
+		return 1 - math.Exp(-x)*(1+x+x*x/2)
+	}
+	tests := []struct {
+		name   string
+		sample func(r *Rand) float64
+		cdf    func(x float64) float64
+	}{
+		{name: "normal",
+			sample: func(r *Rand) float64 { return r.Normal(3, 2) },
+			cdf:    func(x float64) float64 { return 0.5 * (1 + math.Erf((x-3)/(2*math.Sqrt2))) }},
+		{name: "lognormal",
+			sample: func(r *Rand) float64 { return r.LogNormal(0.5, 0.25) },
+			cdf:    func(x float64) float64 { return 0.5 * (1 + math.Erf((math.Log(x)-0.5)/(0.25*math.Sqrt2))) }},
+		{name: "exponential",
+			sample: func(r *Rand) float64 { return r.Exponential(1.5) },
+			cdf:    func(x float64) float64 { return 1 - math.Exp(-1.5*x) }},
+		{name: "gamma",
+			sample: func(r *Rand) float64 { return r.Gamma(3, 2) },
+			cdf:    gamma3},
+		{name: "beta 2,2",
+			sample: func(r *Rand) float64 { return r.Beta(2, 2) },
+			cdf:    func(x float64) float64 { return 3*x*x - 2*x*x*x }},
+		{name: "beta 1,3",
+			sample: func(r *Rand) float64 { return r.Beta(1, 3) },
+			cdf:    func(x float64) float64 { return 1 - math.Pow(1-x, 3) }},
+		{name: "triangular",
+			sample: func(r *Rand) float64 { return r.Triangular(1, 2, 5) },
+			cdf: func(x float64) float64 {
+				if x < 2 {
+					return (x - 1) * (x - 1) / (4 * 1)
+				}
+				return 1 - (5-x)*(5-x)/(4*3)
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(NewXoshiro256(1))
+			samples := make([]float64, distN)
+			for i := range samples {
+				samples[i] = tt.sample(r)
+			}
+			if d := ksStatistic(samples, tt.cdf); d > ksCritical(distN) {
+				t.Errorf("KS statistic %g > %g", d, ksCritical(distN))
+			}
+		})
+	}
+}
+
+func TestRand_Gamma_SmallShape(t *testing.T) {
+	// shape < 1 uses a different path. check mean and variance (k*theta, k*theta^2)
+	r := New(NewXoshiro256(1))
+	const k, theta = 0.5, 2.0
+	var sum, sumSq float64
+	for i := 0; i < distN; i++ {
+		x := r.Gamma(k, theta)
+		sum += x
+		sumSq += x * x
+	}
+	mean := sum / distN
+	variance := sumSq/distN - mean*mean
+	if math.Abs(mean-k*theta) > 0.05 {
+		t.Errorf("mean = %g, want %g", mean, k*theta)
+	}
+	if math.Abs(variance-k*theta*theta) > 0.2 {
+		t.Errorf("variance = %g, want %g", variance, k*theta*theta)
+	}
+}
+
+// probability of k in a poisson distribution, computed in log space so
+// large k don't overflow.
+func poissonPMF(lambda float64, k int) float64 {
+	lg, _ := math.Lgamma(float64(k + 1))
+	return math.Exp(float64(k)*math.Log(lambda) - lambda - lg)
+}
+
+// probability of k successes in a binomial distribution.
+func binomialPMF(n int, p float64, k int) float64 {
+	if k > n {
+		return 0
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return math.Exp(a - b - c + float64(k)*math.Log(p) + float64(n-k)*math.Log(1-p))
+}
+
+func TestRand_DiscreteDistributions(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample func(r *Rand) int
+		pmf    func(k int) float64
+	}{
+		{name: "poisson small",
+			sample: func(r *Rand) int { return r.Poisson(4) },
+			pmf:    func(k int) float64 { return poissonPMF(4, k) }},
+		{name: "poisson at cutoff",
+			sample: func(r *Rand) int { return r.Poisson(30) },
+			pmf:    func(k int) float64 { return poissonPMF(30, k) }},
+		{name: "poisson large",
+			sample: func(r *Rand) int { return r.Poisson(120) },
+			pmf:    func(k int) float64 { return poissonPMF(120, k) }},
+		{name: "binomial small",
+			sample: func(r *Rand) int { return r.Binomial(20, 0.3) },
+			pmf:    func(k int) float64 { return binomialPMF(20, 0.3, k) }},
+		{name: "binomial large",
+			sample: func(r *Rand) int { return r.Binomial(300, 0.4) },
+			pmf:    func(k int) float64 { return binomialPMF(300, 0.4, k) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(NewPCG(1, 1))
+			samples := make([]int, distN)
+			for i := range samples {
+				samples[i] = tt.sample(r)
+			}
+			chi, dof := chiSquarePMF(samples, tt.pmf)
+			if crit := chiSquareCritical(dof); chi > crit {
+				t.Errorf("chi-square %g > %g (%d dof)", chi, crit, dof)
+			}
+		})
+	}
+}
+
+func TestAlias(t *testing.T) {
+	weights := []float64{1, 0, 2, 7, 0.5, 3}
+	total := 13.5
+	a := NewAlias(weights)
+	r := New(NewSplitMix64(3))
+	samples := make([]int, distN)
+	for i := range samples {
+		samples[i] = a.Sample(r)
+		if samples[i] == 1 {
+			t.Fatal("sampled a 0 weight")
+		}
+	}
+	chi, dof := chiSquarePMF(samples, func(k int) float64 {
+		if k >= len(weights) {
+			return 0
+		}
+		return weights[k] / total
+	})
+	if crit := chiSquareCritical(dof); chi > crit {
+		t.Errorf("chi-square %g > %g (%d dof)", chi, crit, dof)
+	}
+}
+
+func TestNewAliasFromCDF(t *testing.T) {
+	a := NewAliasFromCDF([]float64{0.25, 0.25, 1})
+	r := New(NewSplitMix64(3))
+	counts := make([]int, 3)
+	for i := 0; i < distN; i++ {
+		counts[a.Sample(r)]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("sampled index 1 %d times", counts[1])
+	}
+	if got := float64(counts[0]) / distN; math.Abs(got-0.25) > 0.02 {
+		t.Errorf("index 0 sampled %g of the time", got)
+	}
+}
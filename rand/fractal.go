@@ -0,0 +1,219 @@
+package rand
+
+import (
+	"github.com/quillaja/goutil/num"
+)
+
+// FractalMode determines how the octaves of a Fractal are combined.
+type FractalMode int
+
+const (
+	// FBM (fractional brownian motion) adds octaves together. It is the same
+	// sum as Noise3Octaves, except Noise3Octaves negates its result and FBM
+	// does not. The result is in [-1,1] for a source in [-1,1].
+	FBM FractalMode = iota
+	// Billow adds the absolute value of each octave, giving a puffy, cloud-like
+	// look. The result is in [-1,1].
+	Billow
+	// Turbulence adds the absolute value of each octave without remapping,
+	// giving sharp creases. The result is in [0,1].
+	Turbulence
+	// Ridged is Musgrave's ridged multifractal, which inverts Turbulence and
+	// weights each octave by the previous one to give sharp ridges like
+	// mountain ranges. The result is in [-1,1].
+	Ridged
+	// HybridMulti is Musgrave's hybrid multifractal, which gives smooth valleys
+	// and rough peaks. The result is roughly, but not strictly, in [-1,1].
+	HybridMulti
+)
+
+// Fractal combines several octaves of any noise source to add a "fractal"
+// quality to the detail of the noise produced. It works with Noise3 (via
+// Noise3DFunc), CellNoise3D, CellNoise2D (via Lift2D) or anything else that
+// implements Noise3D.
+//
+// Every mode except FBM assumes the source produces values in about [-1,1].
+// Use Signed() to adapt sources in [0,1] such as cell noise.
+//
+// See: "Texturing and Modeling: A Procedural Approach", ch. 16 (F. K. Musgrave)
+// and: http://flafla2.github.io/2014/08/09/perlinnoise.html
+type Fractal struct {
+	Source Noise3D
+	Mode   FractalMode
+
+	// Octaves is the number of samples taken and added together.
+	Octaves int
+	// Frequency is the frequency of the first octave.
+	Frequency float64
+	// Lacunarity is the rate at which the frequency increases each octave.
+	Lacunarity float64
+	// Persistence is the rate at which the amplitude decreases each octave.
+	Persistence float64
+	// Offset is added to each octave in Ridged and HybridMulti modes.
+	Offset float64
+	// Gain controls how much each octave is weighted by the previous
+	// one in Ridged mode.
+	Gain float64
+	// Rotate rotates and shifts the domain of each octave so the lattice
+	// of the source doesn't line up between octaves, which hides grid
+	// artifacts.
+	Rotate bool
+}
+
+// NewFractal creates a Fractal with reasonable default parameters: 4 octaves,
+// frequency 1, lacunarity 2, persistence 0.5, and rotation enabled.
+func NewFractal(source Noise3D, mode FractalMode) *Fractal {
+	f := &Fractal{
+		Source:      source,
+		Mode:        mode,
+		Octaves:     4,
+		Frequency:   1,
+		Lacunarity:  2,
+		Persistence: 0.5,
+		Offset:      1,
+		Gain:        2,
+		Rotate:      true,
+	}
+	if mode == HybridMulti {
+		f.Offset = 0.7
+	}
+	return f
+}
+
+// Noise gets the fractal noise value at (x, y, z).
+func (f *Fractal) Noise(x, y, z float64) float64 {
+	return FractalOf(f, f.Source.Noise, x, y, z)
+}
+
+// FractalOf is the same as f.Noise but works on float32 as well as float64.
+// It uses f's settings but samples source instead of f.Source, so a float32
+// fractal can be made with Noise3Of[float32]. Since methods can't be generic,
+// this is a function.
+func FractalOf[T num.Float](f *Fractal, source func(x, y, z T) T, x, y, z T) T {
+	freq, lac := T(f.Frequency), T(f.Lacunarity)
+	x, y, z = x*freq, y*freq, z*freq
+	s := newOctaveSum[T](f)
+	for i := 0; i < f.Octaves; i++ {
+		s.add(source(x, y, z))
+		if f.Rotate {
+			x, y, z = rotateOctave3(x, y, z)
+		}
+		x, y, z = x*lac, y*lac, z*lac
+	}
+	return s.result()
+}
+
+// Noise2 gets the fractal noise value at (x, y, 0). Rotation, if enabled,
+// is only done in the xy plane, so Noise2 is suitable for sources made
+// with Lift2D.
+func (f *Fractal) Noise2(x, y float64) float64 {
+	x, y = x*f.Frequency, y*f.Frequency
+	s := newOctaveSum[float64](f)
+	for i := 0; i < f.Octaves; i++ {
+		s.add(f.Source.Noise(x, y, 0))
+		if f.Rotate {
+			x, y = rotateOctave2(x, y)
+		}
+		x, y = x*f.Lacunarity, y*f.Lacunarity
+	}
+	return s.result()
+}
+
+// Signed adapts a source producing values in [0,1] (such as cell noise)
+// to produce values in [-1,1].
+func Signed(n Noise3D) Noise3D {
+	return Noise3DFunc(func(x, y, z float64) float64 {
+		return 2*n.Noise(x, y, z) - 1
+	})
+}
+
+// rotates (x,y,z) by a fixed orthonormal matrix and shifts it a bit, so that
+// an octave's lattice doesn't line up with the previous one's.
+// the matrix is the one Inigo Quilez uses in his fbm articles.
+func rotateOctave3[T num.Float](x, y, z T) (T, T, T) {
+	return 0.00*x + 0.80*y + 0.60*z + 17.13,
+		-0.80*x + 0.36*y - 0.48*z + 31.71,
+		-0.60*x - 0.48*y + 0.64*z + 7.37
+}
+
+// 2D version of rotateOctave3().
+func rotateOctave2(x, y float64) (float64, float64) {
+	return 0.80*x + 0.60*y + 17.13,
+		-0.60*x + 0.80*y + 31.71
+}
+
+// accumulates octaves for a Fractal according to its mode.
+type octaveSum[T num.Float] struct {
+	mode         FractalMode
+	persistence  T
+	offset, gain T
+
+	total, maxVal T
+	amplitude     T
+	weight        T
+	first         bool
+}
+
+func newOctaveSum[T num.Float](f *Fractal) octaveSum[T] {
+	return octaveSum[T]{
+		mode:        f.Mode,
+		persistence: T(f.Persistence),
+		offset:      T(f.Offset),
+		gain:        T(f.Gain),
+		amplitude:   1,
+		weight:      1,
+		first:       true,
+	}
+}
+
+// adds the next octave's noise value n.
+func (s *octaveSum[T]) add(n T) {
+	switch s.mode {
+	case Billow:
+		s.total += (2*num.Abs(n) - 1) * s.amplitude
+		s.maxVal += s.amplitude
+
+	case Turbulence:
+		s.total += num.Abs(n) * s.amplitude
+		s.maxVal += s.amplitude
+
+	case Ridged:
+		signal := s.offset - num.Abs(n)
+		signal *= signal * s.weight
+		s.weight = num.Clamp(signal*s.gain, 0, 1)
+		s.total += signal * s.amplitude
+		s.maxVal += s.offset * s.offset * s.amplitude
+
+	case HybridMulti:
+		signal := (n + s.offset) * s.amplitude
+		if s.first {
+			s.total = signal
+			s.weight = signal
+		} else {
+			s.weight = num.Clamp(s.weight, 0, 1)
+			s.total += s.weight * signal
+			s.weight *= signal
+		}
+		s.maxVal += (1 + s.offset) * s.amplitude
+
+	default: // FBM
+		s.total += n * s.amplitude
+		s.maxVal += s.amplitude
+	}
+
+	s.first = false
+	s.amplitude *= s.persistence
+}
+
+// gets the normalized result.
+func (s *octaveSum[T]) result() T {
+	if s.maxVal == 0 {
+		return 0
+	}
+	switch s.mode {
+	case Ridged, HybridMulti:
+		return 2*(s.total/s.maxVal) - 1 // [0,1] to [-1,1]
+	default:
+		return s.total / s.maxVal
+	}
+}
@@ -0,0 +1,98 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quillaja/goutil/data"
+)
+
+func TestFractal_Range(t *testing.T) {
+	FillPermutation(1)
+	tests := []struct {
+		name     string
+		mode     FractalMode
+		low, top float64
+	}{
+		{name: "fbm", mode: FBM, low: -1, top: 1},
+		{name: "billow", mode: Billow, low: -1, top: 1},
+		{name: "turbulence", mode: Turbulence, low: 0, top: 1},
+		{name: "ridged", mode: Ridged, low: -1, top: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFractal(Noise3DFunc(Noise3), tt.mode)
+			for i := 0; i < testN; i++ {
+				x, y, z := float64(i)*0.137, float64(i)*0.071, float64(i)*0.013
+				got := f.Noise(x, y, z)
+				if !(tt.low <= got && got <= tt.top) || math.IsNaN(got) {
+					t.Fatalf("%g <= %g <= %g at (%g,%g,%g)", tt.low, got, tt.top, x, y, z)
+				}
+			}
+		})
+	}
+}
+
+func TestFractal_FBMMatchesSum(t *testing.T) {
+	// without rotation, FBM should be the plain normalized sum of octaves
+	FillPermutation(1)
+	f := NewFractal(Noise3DFunc(Noise3), FBM)
+	f.Rotate = false
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.31, float64(i)*0.17, 0.5
+		want, freq, amp, max := 0.0, 1.0, 1.0, 0.0
+		for o := 0; o < f.Octaves; o++ {
+			want += Noise3(x*freq, y*freq, z*freq) * amp
+			max += amp
+			freq *= 2
+			amp *= 0.5
+		}
+		want /= max
+		if got := f.Noise(x, y, z); math.Abs(got-want) > 1e-12 {
+			t.Errorf("got %g, want %g", got, want)
+		}
+		// Noise3Octaves is the same sum, negated
+		if got := -Noise3Octaves(x, y, z, 4, 2, 0.5); math.Abs(got-want) > 1e-12 {
+			t.Errorf("-Noise3Octaves: got %g, want %g", got, want)
+		}
+	}
+}
+
+func TestFractal_CellNoise(t *testing.T) {
+	// any source should work, including 2D cell noise
+	cell := NewCellNoise2D(1, 2, 5, data.Euclidean)
+	f := NewFractal(Signed(Lift2D(cell)), Ridged)
+	a, b := f.Noise2(1.5, 2.5), f.Noise2(1.5, 2.5)
+	if a != b {
+		t.Errorf("not deterministic: %g != %g", a, b)
+	}
+	if a < -1 || a > 1 {
+		t.Errorf("out of range: %g", a)
+	}
+}
+
+func TestFractalOf(t *testing.T) {
+	FillPermutation(1)
+	for _, mode := range []FractalMode{FBM, Billow, Turbulence, Ridged, HybridMulti} {
+		f := NewFractal(Noise3DFunc(Noise3), mode)
+		for i := 0; i < 1000; i++ {
+			x, y, z := float64(i)*0.137, float64(i)*0.071, float64(i)*0.013
+			want := f.Noise(x, y, z)
+			if got := FractalOf(f, Noise3Of[float64], x, y, z); got != want {
+				t.Fatalf("mode %d: float64 %g != %g at (%g,%g,%g)", mode, got, want, x, y, z)
+			}
+			got := FractalOf(f, Noise3Of[float32], float32(x), float32(y), float32(z))
+			if math.Abs(float64(got)-want) > 1e-3 {
+				t.Fatalf("mode %d: float32 %g != %g at (%g,%g,%g)", mode, got, want, x, y, z)
+			}
+		}
+	}
+}
+
+func BenchmarkFractal_FBM(b *testing.B) {
+	f := NewFractal(Noise3DFunc(Noise3), FBM)
+	for i := 0; i < b.N; i++ {
+		offset := float64(i) / float64(b.N)
+		f.Noise(offset, offset, offset)
+	}
+}
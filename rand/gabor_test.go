@@ -0,0 +1,147 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+// mean of n(p)*n(p+lag) over points along a diagonal line, which is the
+// autocorrelation of the noise at that lag (its mean is 0).
+func autocorr2(n Noise2D, lagX, lagY float64) float64 {
+	sum := 0.0
+	for i := 0; i < testN; i++ {
+		x, y := float64(i)*0.377, float64(i)*0.213
+		sum += n.Noise(x, y) * n.Noise(x+lagX, y+lagY)
+	}
+	return sum / testN
+}
+
+// sample mean and standard deviation.
+func meanStdDev(values []float64) (mean, sd float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		sd += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(values)-1))
+}
+
+func TestGabor2D_Stats(t *testing.T) {
+	tests := []struct {
+		name  string
+		noise Noise2D
+	}{
+		{"oriented", NewGabor2D(1, 0.3, 2, 0.5, 64)},
+		{"isotropic", NewIsotropicGabor2D(1, 2, 0.5, 64)},
+		{"sparse", NewGabor2D(2, 0, 4, 1, 8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := make([]float64, testN)
+			for i := range values {
+				values[i] = tt.noise.Noise(float64(i)*0.377, float64(i)*0.213)
+			}
+			mean, sd := meanStdDev(values)
+			if math.Abs(mean) > 0.05 {
+				t.Errorf("mean %g, want about 0", mean)
+			}
+			if sd < 0.25 || 0.42 < sd {
+				t.Errorf("std dev %g, want about 1/3", sd)
+			}
+		})
+	}
+}
+
+func TestGabor2D_Orientation(t *testing.T) {
+	// waves travel along x at 2 per unit, so half a wave along x is
+	// anti-correlated, and the same distance across the stripes is correlated.
+	g := NewGabor2D(1, 0, 2, 0.5, 64)
+	if c := autocorr2(g, 0.25, 0); c > -0.05 {
+		t.Errorf("along: autocorrelation %g, want negative", c)
+	}
+	if c := autocorr2(g, 0, 0.25); c < 0.05 {
+		t.Errorf("across: autocorrelation %g, want positive", c)
+	}
+
+	// without an orientation, both are the same
+	iso := NewIsotropicGabor2D(1, 2, 0.5, 64)
+	if a, b := autocorr2(iso, 0.25, 0), autocorr2(iso, 0, 0.25); math.Abs(a-b) > 0.02 {
+		t.Errorf("isotropic autocorrelations %g and %g differ", a, b)
+	}
+}
+
+func TestGabor3D(t *testing.T) {
+	// waves along z
+	g := NewGabor3D(1, [3]float64{0, 0, 3}, 2, 0.5, 32)
+	values := make([]float64, testN/4)
+	along, across := 0.0, 0.0
+	for i := range values {
+		x, y, z := float64(i)*0.377, float64(i)*0.213, float64(i)*0.101
+		values[i] = g.Noise(x, y, z)
+		along += values[i] * g.Noise(x, y, z+0.25)
+		across += values[i] * g.Noise(x+0.25, y, z)
+	}
+	mean, sd := meanStdDev(values)
+	if math.Abs(mean) > 0.05 || sd < 0.25 || 0.42 < sd {
+		t.Errorf("mean %g, std dev %g", mean, sd)
+	}
+	if along >= 0 || across <= 0 {
+		t.Errorf("autocorrelations along %g, across %g", along, across)
+	}
+
+	iso := NewIsotropicGabor3D(1, 2, 0.5, 32)
+	for i := range values {
+		values[i] = iso.Noise(float64(i)*0.377, float64(i)*0.213, float64(i)*0.101)
+	}
+	if mean, sd := meanStdDev(values); math.Abs(mean) > 0.05 || sd < 0.25 || 0.42 < sd {
+		t.Errorf("isotropic mean %g, std dev %g", mean, sd)
+	}
+}
+
+func TestGabor_Deterministic(t *testing.T) {
+	a, b := NewGabor2D(5, 1, 3, 1, 16), NewGabor2D(5, 1, 3, 1, 16)
+	c := NewGabor2D(6, 1, 3, 1, 16)
+	same := 0
+	for i := 0; i < 1000; i++ {
+		x, y := float64(i)*0.37-100, float64(i)*0.21-50
+		if a.Noise(x, y) != b.Noise(x, y) {
+			t.Fatalf("same seed differs at (%g,%g)", x, y)
+		}
+		if a.Noise(x, y) == c.Noise(x, y) {
+			same++
+		}
+	}
+	if same > 10 {
+		t.Errorf("different seeds gave %d equal values", same)
+	}
+}
+
+func TestGabor_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"bandwidth", func() { NewGabor2D(1, 0, 1, 0, 10) }},
+		{"density", func() { NewIsotropicGabor2D(1, 1, 1, -1) }},
+		{"orientation", func() { NewGabor3D(1, [3]float64{}, 1, 1, 10) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func BenchmarkGabor2D(b *testing.B) {
+	g := NewGabor2D(1, 0.5, 2, 0.5, 64)
+	for i := 0; i < b.N; i++ {
+		g.Noise(float64(i)*0.137, 1.3)
+	}
+}
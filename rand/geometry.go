@@ -0,0 +1,125 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// Angle gets a random angle in radians in [0,2PI).
+func (r *Rand) Angle() float64 {
+	return 2 * math.Pi * r.Float64()
+}
+
+// UnitVector2 gets a random 2D vector of length 1.
+func (r *Rand) UnitVector2() (x, y float64) {
+	return r.OnCircle(1)
+}
+
+// UnitVector3 gets a random 3D vector of length 1.
+func (r *Rand) UnitVector3() (x, y, z float64) {
+	return r.OnSphere(1)
+}
+
+// OnCircle gets a uniformly distributed random point on the circle
+// of the given radius centered on the origin.
+func (r *Rand) OnCircle(radius float64) (x, y float64) {
+	s, c := math.Sincos(r.Angle())
+	return radius * c, radius * s
+}
+
+// InDisk gets a uniformly distributed random point inside the disk of the
+// given radius centered on the origin. The square root of the distance from
+// the center is used, so points don't clump in the middle.
+func (r *Rand) InDisk(radius float64) (x, y float64) {
+	d := radius * math.Sqrt(r.Float64())
+	s, c := math.Sincos(r.Angle())
+	return d * c, d * s
+}
+
+// OnSphere gets a uniformly distributed random point on the surface of the
+// sphere of the given radius centered on the origin.
+func (r *Rand) OnSphere(radius float64) (x, y, z float64) {
+	// z is uniform in [-1,1] (Archimedes' hat-box theorem)
+	z = 2*r.Float64() - 1
+	rxy := math.Sqrt(1 - z*z)
+	s, c := math.Sincos(r.Angle())
+	return radius * rxy * c, radius * rxy * s, radius * z
+}
+
+// InSphere gets a uniformly distributed random point inside the sphere
+// of the given radius centered on the origin.
+func (r *Rand) InSphere(radius float64) (x, y, z float64) {
+	x, y, z = r.OnSphere(radius * math.Cbrt(r.Float64()))
+	return
+}
+
+// InTriangle gets a uniformly distributed random point inside the
+// triangle abc.
+func (r *Rand) InTriangle(a, b, c [2]float64) [2]float64 {
+	u, v := r.Float64(), r.Float64()
+	if u+v > 1 {
+		// reflect into the lower half of the parallelogram
+		u, v = 1-u, 1-v
+	}
+	return [2]float64{
+		a[0] + u*(b[0]-a[0]) + v*(c[0]-a[0]),
+		a[1] + u*(b[1]-a[1]) + v*(c[1]-a[1]),
+	}
+}
+
+// OnPolygon gets a uniformly distributed random point along the perimeter
+// of the closed polygon with the given vertices (the last vertex connects
+// back to the first). Panics if there are fewer than 2 vertices.
+func (r *Rand) OnPolygon(vertices [][2]float64) [2]float64 {
+	if len(vertices) < 2 {
+		panic(fmt.Errorf("Invalid params: polygon needs at least 2 vertices"))
+	}
+	edge := func(i int) (a, b [2]float64) {
+		return vertices[i], vertices[(i+1)%len(vertices)]
+	}
+
+	perimeter := 0.0
+	for i := range vertices {
+		a, b := edge(i)
+		perimeter += math.Hypot(b[0]-a[0], b[1]-a[1])
+	}
+
+	t := r.Float64() * perimeter
+	for i := range vertices {
+		a, b := edge(i)
+		l := math.Hypot(b[0]-a[0], b[1]-a[1])
+		if t < l || i == len(vertices)-1 {
+			f := 0.0
+			if l > 0 {
+				f = math.Min(t/l, 1)
+			}
+			return [2]float64{a[0] + f*(b[0]-a[0]), a[1] + f*(b[1]-a[1])}
+		}
+		t -= l
+	}
+	return vertices[0] // never happens
+}
+
+// Rotation gets a uniformly distributed random rotation, as a unit
+// quaternion (w, x, y, z). Uses Shoemake's method.
+//
+// See: Graphics Gems III, "Uniform Random Rotations" (K. Shoemake)
+func (r *Rand) Rotation() [4]float64 {
+	u1, u2, u3 := r.Float64(), 2*math.Pi*r.Float64(), 2*math.Pi*r.Float64()
+	a, b := math.Sqrt(1-u1), math.Sqrt(u1)
+	s2, c2 := math.Sincos(u2)
+	s3, c3 := math.Sincos(u3)
+	return [4]float64{b * c3, a * s2, a * c2, b * s3}
+}
+
+// RotationMatrix gets a uniformly distributed random rotation as a
+// 3x3 row-major matrix.
+func (r *Rand) RotationMatrix() [3][3]float64 {
+	q := r.Rotation()
+	w, x, y, z := q[0], q[1], q[2], q[3]
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
@@ -0,0 +1,173 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+const geoN = 40000 // samples per geometry test
+
+// checks counts against a uniform expectation with a chi-square test.
+func checkUniform(t *testing.T, what string, counts []int) {
+	t.Helper()
+	chi := chiSquareUniform(counts)
+	if crit := chiSquareCritical(len(counts) - 1); chi > crit {
+		t.Errorf("%s not uniform: chi-square %g > %g, counts %v", what, chi, crit, counts)
+	}
+}
+
+func TestRand_InDisk(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	const radius = 3
+	// 10 rings of equal area, and 10 equal sectors
+	rings, sectors := make([]int, 10), make([]int, 10)
+	for i := 0; i < geoN; i++ {
+		x, y := r.InDisk(radius)
+		d := math.Hypot(x, y)
+		if d > radius {
+			t.Fatalf("(%g,%g) outside disk", x, y)
+		}
+		rings[int(d*d/(radius*radius)*10)]++
+		a := math.Atan2(y, x) + math.Pi
+		sectors[int(a/(2*math.Pi)*10)%10]++
+	}
+	checkUniform(t, "rings", rings)
+	checkUniform(t, "sectors", sectors)
+}
+
+func TestRand_OnCircle(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	sectors := make([]int, 12)
+	for i := 0; i < geoN; i++ {
+		x, y := r.UnitVector2()
+		if math.Abs(math.Hypot(x, y)-1) > 1e-12 {
+			t.Fatalf("(%g,%g) not unit length", x, y)
+		}
+		a := math.Atan2(y, x) + math.Pi
+		sectors[int(a/(2*math.Pi)*12)%12]++
+	}
+	checkUniform(t, "sectors", sectors)
+}
+
+func TestRand_OnSphere(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	// by Archimedes, each coordinate of a uniform point on a sphere is uniform
+	var xs, zs [10]int
+	for i := 0; i < geoN; i++ {
+		x, y, z := r.UnitVector3()
+		if math.Abs(math.Sqrt(x*x+y*y+z*z)-1) > 1e-12 {
+			t.Fatalf("(%g,%g,%g) not unit length", x, y, z)
+		}
+		xs[int(math.Min((x+1)/2*10, 9))]++
+		zs[int(math.Min((z+1)/2*10, 9))]++
+	}
+	checkUniform(t, "x", xs[:])
+	checkUniform(t, "z", zs[:])
+}
+
+func TestRand_InSphere(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	const radius = 2
+	// 10 shells of equal volume
+	shells := make([]int, 10)
+	for i := 0; i < geoN; i++ {
+		x, y, z := r.InSphere(radius)
+		d := math.Sqrt(x*x+y*y+z*z) / radius
+		if d > 1 {
+			t.Fatalf("(%g,%g,%g) outside sphere", x, y, z)
+		}
+		shells[int(math.Min(d*d*d*10, 9))]++
+	}
+	checkUniform(t, "shells", shells)
+}
+
+func TestRand_InTriangle(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	a, b, c := [2]float64{0, 0}, [2]float64{4, 0}, [2]float64{1, 3}
+	// the midpoints split the triangle into 4 of equal area
+	ab := [2]float64{2, 0}
+	bc := [2]float64{2.5, 1.5}
+	ca := [2]float64{0.5, 1.5}
+	// which side of line pq is point x on
+	side := func(p, q, x [2]float64) bool {
+		return (q[0]-p[0])*(x[1]-p[1])-(q[1]-p[1])*(x[0]-p[0]) > 0
+	}
+	counts := make([]int, 4)
+	for i := 0; i < geoN; i++ {
+		p := r.InTriangle(a, b, c)
+		if !side(a, b, p) || !side(b, c, p) || !side(c, a, p) {
+			t.Fatalf("%v outside triangle", p)
+		}
+		switch {
+		case !side(ca, ab, p):
+			counts[0]++ // corner a
+		case !side(ab, bc, p):
+			counts[1]++ // corner b
+		case !side(bc, ca, p):
+			counts[2]++ // corner c
+		default:
+			counts[3]++ // middle
+		}
+	}
+	checkUniform(t, "sub-triangles", counts)
+}
+
+func TestRand_OnPolygon(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	// a 3x1 rectangle: the long sides should get 3 times the points
+	rect := [][2]float64{{0, 0}, {3, 0}, {3, 1}, {0, 1}}
+	counts := make([]int, 8) // each long side split in 3
+	for i := 0; i < geoN; i++ {
+		p := r.OnPolygon(rect)
+		switch {
+		case p[1] == 0:
+			counts[int(math.Min(p[0], 2.999))]++
+		case p[1] == 1:
+			counts[3+int(math.Min(p[0], 2.999))]++
+		case p[0] == 3:
+			counts[6]++
+		case p[0] == 0:
+			counts[7]++
+		default:
+			t.Fatalf("%v not on perimeter", p)
+		}
+	}
+	checkUniform(t, "unit lengths of perimeter", counts)
+}
+
+func TestRand_Rotation(t *testing.T) {
+	r := New(NewXoshiro256(1))
+	var zs [10]int
+	for i := 0; i < geoN; i++ {
+		q := r.Rotation()
+		if l := q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3]; math.Abs(l-1) > 1e-12 {
+			t.Fatalf("%v not a unit quaternion", q)
+		}
+
+		m := r.RotationMatrix()
+		// rows are orthonormal and the determinant is 1
+		for a := 0; a < 3; a++ {
+			for b := 0; b < 3; b++ {
+				dot := m[a][0]*m[b][0] + m[a][1]*m[b][1] + m[a][2]*m[b][2]
+				want := 0.0
+				if a == b {
+					want = 1
+				}
+				if math.Abs(dot-want) > 1e-12 {
+					t.Fatalf("%v not orthonormal", m)
+				}
+			}
+		}
+		det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+		if math.Abs(det-1) > 1e-12 {
+			t.Fatalf("determinant %g", det)
+		}
+
+		// a rotated fixed vector should be uniform on the sphere
+		z := m[2][2]
+		zs[int(math.Min((z+1)/2*10, 9))]++
+	}
+	checkUniform(t, "rotated z", zs[:])
+}
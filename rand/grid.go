@@ -0,0 +1,275 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/quillaja/goutil/num"
+)
+
+// FillGrid2D fills dst with Noise2 over a width x height grid.
+// See FillGrid3D.
+func FillGrid2D(dst []float64, width, height int, origin [2]float64, step float64) {
+	perlinGrid(p, dst, width, height, 1, [3]float64{origin[0], origin[1], defaultZ}, step)
+}
+
+// FillGrid3D fills dst with Noise3 over a width x height x depth grid, much
+// faster than calling Noise3 for each point. Work that only depends on the
+// row or column of the grid is done once and reused, and the rows are split
+// between GOMAXPROCS goroutines. The other FillGrid functions and methods
+// work the same way.
+//
+// dst is filled in row-major order: the value at grid point (i, j, k) is
+// dst[(k*height+j)*width+i], and is the noise at
+// (origin[0]+i*step, origin[1]+j*step, origin[2]+k*step). A depth of 1 gives
+// a slice of the noise in the xy plane at origin[2]. Panics if dst is too
+// small.
+func FillGrid3D(dst []float64, width, height, depth int, origin [3]float64, step float64) {
+	perlinGrid(p, dst, width, height, depth, origin, step)
+}
+
+// FillGrid2D fills dst with the noise in the xy plane at z = 0 over a
+// width x height grid. See FillGrid3D.
+func (n *Perlin) FillGrid2D(dst []float64, width, height int, origin [2]float64, step float64) {
+	perlinGrid(n.perm, dst, width, height, 1, [3]float64{origin[0], origin[1], 0}, step)
+}
+
+// FillGrid3D fills dst with the noise over a width x height x depth grid.
+// See the package-level FillGrid3D.
+func (n *Perlin) FillGrid3D(dst []float64, width, height, depth int, origin [3]float64, step float64) {
+	perlinGrid(n.perm, dst, width, height, depth, origin, step)
+}
+
+// FillGrid2D fills dst with Noise over a width x height grid.
+// See FillGrid3D.
+func (w *Worley2D) FillGrid2D(dst []float64, width, height int, origin [2]float64, step float64) {
+	checkGrid(len(dst), width, height, 1)
+	x0, x1 := cellSpan(width, origin[0], step)
+	fillRows(height, func(lo, hi int) {
+		var s cellStrip
+		yc := math.MinInt
+		for r := lo; r < hi; r++ {
+			y := origin[1] + float64(r)*step
+			// the neighborhood only changes when the row enters a new cell
+			if c := int(math.Floor(y)); c != yc {
+				yc = c
+				w.fillStrip(&s, x0, x1, yc)
+			}
+			row := dst[r*width : (r+1)*width]
+			for i := range row {
+				row[i] = num.ClampFloat(s.nearest(w.metric, origin[0]+float64(i)*step, y, 0), 0, 1)
+			}
+		}
+	})
+}
+
+// places the feature points of cell columns [x0-1, x1+1] in rows
+// [yc-1, yc+1] into s.
+func (w *Worley2D) fillStrip(s *cellStrip, x0, x1, yc int) {
+	s.reset(x0 - 1)
+	for xc := x0 - 1; xc <= x1+1; xc++ {
+		for c := yc - 1; c <= yc+1; c++ {
+			rng := newCellRNG(w.key, int64(xc), int64(c))
+			for npts := pointsInCell(w.cdf, w.maxPtsPerCell, rng.float64()); npts > 0; npts-- {
+				px := float64(xc) + rng.float64()
+				py := float64(c) + rng.float64()
+				s.pts = append(s.pts, [3]float64{px, py, 0})
+			}
+		}
+		s.end = append(s.end, len(s.pts))
+	}
+}
+
+// FillGrid3D fills dst with Noise over a width x height x depth grid.
+// See the package-level FillGrid3D.
+func (w *Worley3D) FillGrid3D(dst []float64, width, height, depth int, origin [3]float64, step float64) {
+	checkGrid(len(dst), width, height, depth)
+	x0, x1 := cellSpan(width, origin[0], step)
+	fillRows(height*depth, func(lo, hi int) {
+		var s cellStrip
+		yc, zc := math.MinInt, math.MinInt
+		for r := lo; r < hi; r++ {
+			y := origin[1] + float64(r%height)*step
+			z := origin[2] + float64(r/height)*step
+			if cy, cz := int(math.Floor(y)), int(math.Floor(z)); cy != yc || cz != zc {
+				yc, zc = cy, cz
+				w.fillStrip(&s, x0, x1, yc, zc)
+			}
+			row := dst[r*width : (r+1)*width]
+			for i := range row {
+				row[i] = num.ClampFloat(s.nearest(w.metric, origin[0]+float64(i)*step, y, z), 0, 1)
+			}
+		}
+	})
+}
+
+// places the feature points of cell columns [x0-1, x1+1] in rows
+// [yc-1, yc+1] and layers [zc-1, zc+1] into s.
+func (w *Worley3D) fillStrip(s *cellStrip, x0, x1, yc, zc int) {
+	s.reset(x0 - 1)
+	for xc := x0 - 1; xc <= x1+1; xc++ {
+		for cz := zc - 1; cz <= zc+1; cz++ {
+			for cy := yc - 1; cy <= yc+1; cy++ {
+				rng := newCellRNG(w.key, int64(xc), int64(cy), int64(cz))
+				for npts := pointsInCell(w.cdf, w.maxPtsPerCell, rng.float64()); npts > 0; npts-- {
+					px := float64(xc) + rng.float64()
+					py := float64(cy) + rng.float64()
+					pz := float64(cz) + rng.float64()
+					s.pts = append(s.pts, [3]float64{px, py, pz})
+				}
+			}
+		}
+		s.end = append(s.end, len(s.pts))
+	}
+}
+
+// the feature points of a strip of cells along the x axis, which is all
+// that's needed to evaluate cell noise along a row of a grid.
+type cellStrip struct {
+	x0  int          // cell column of the first column in the strip
+	end []int        // the points of column i are pts[end[i-1]:end[i]]
+	pts [][3]float64 // all the points, in column order
+}
+
+// empties the strip, keeping its memory.
+func (s *cellStrip) reset(x0 int) {
+	s.x0 = x0
+	s.end = s.end[:0]
+	s.pts = s.pts[:0]
+}
+
+// gets the distance from (x,y,z) to the nearest point in the 3 columns
+// around it.
+func (s *cellStrip) nearest(m Metric, x, y, z float64) float64 {
+	i := int(math.Floor(x)) - 1 - s.x0 // column left of x's
+	lo := 0
+	if i > 0 {
+		lo = s.end[i-1]
+	}
+	f := math.Inf(1)
+	for _, p := range s.pts[lo:s.end[i+2]] {
+		if d := m.dist(p[0]-x, p[1]-y, p[2]-z); d < f {
+			f = d
+		}
+	}
+	return f
+}
+
+// gets the first and last cell column of a row of samples.
+func cellSpan(width int, origin, step float64) (x0, x1 int) {
+	a := int(math.Floor(origin))
+	b := int(math.Floor(origin + float64(width-1)*step))
+	if a > b {
+		a, b = b, a // negative step
+	}
+	return a, b
+}
+
+// panics if a grid won't fit in dst.
+func checkGrid(n, width, height, depth int) {
+	if width < 0 || height < 0 || depth < 0 || n < width*height*depth {
+		panic(fmt.Errorf("Invalid params: %dx%dx%d grid doesn't fit in %d values", width, height, depth, n))
+	}
+}
+
+// splits rows [0,n) into contiguous chunks, so that neighboring rows can
+// share work, and calls fill on each chunk in its own goroutine.
+func fillRows(n int, fill func(lo, hi int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		fill(0, n)
+		return
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fill(lo, hi)
+		}(lo, min(lo+chunk, n))
+	}
+	wg.Wait()
+}
+
+// the lattice cube, position in the cube, and fade of each sample along
+// one axis of a grid, as in perlin3.
+type gridAxis struct {
+	cube []int
+	frac []float64
+	fade []float64
+}
+
+func newGridAxis(n int, origin, step float64) gridAxis {
+	a := gridAxis{
+		cube: make([]int, n),
+		frac: make([]float64, n),
+		fade: make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		c := origin + float64(i)*step
+		f := math.Floor(c)
+		a.cube[i] = 255 & int(f)
+		a.frac[i] = c - f
+		a.fade[i] = num.SmootherStep(a.frac[i])
+	}
+	return a
+}
+
+// does the work of the perlin FillGrid functions using permutation table p.
+func perlinGrid(p *[512]int, dst []float64, width, height, depth int, origin [3]float64, step float64) {
+	checkGrid(len(dst), width, height, depth)
+	xs := newGridAxis(width, origin[0], step)
+	ys := newGridAxis(height, origin[1], step)
+	zs := newGridAxis(depth, origin[2], step)
+	fillRows(height*depth, func(lo, hi int) {
+		for r := lo; r < hi; r++ {
+			j, k := r%height, r/height
+			perlinRow(p, dst[r*width:(r+1)*width], &xs,
+				ys.cube[j], ys.frac[j], ys.fade[j],
+				zs.cube[k], zs.frac[k], zs.fade[k])
+		}
+	})
+}
+
+// fills one row of a grid of perlin noise. along a row only x changes, and
+// grad() is linear in x, so when the row enters a new cube each corner's
+// gradient is reduced to a*x + c. the results are identical to perlin3.
+func perlinRow(p *[512]int, row []float64, xs *gridAxis, yCube int, y, v float64, zCube int, z, w float64) {
+	var a, c [8]float64 // corners ordered as in latticeCell
+	last := -1
+	for i := range row {
+		xCube, x, u := xs.cube[i], xs.frac[i], xs.fade[i]
+		if xCube != last {
+			last = xCube
+			A := p[xCube] + yCube
+			AA, AB := p[A]+zCube, p[A+1]+zCube
+			B := p[xCube+1] + yCube
+			BA, BB := p[B]+zCube, p[B+1]+zCube
+			h := [8]int{
+				p[AA], p[BA], p[AB], p[BB],
+				p[AA+1], p[BA+1], p[AB+1], p[BB+1],
+			}
+			for k, hash := range h {
+				dy, dz := float64(k>>1&1), float64(k>>2&1)
+				a[k] = grad[float64](hash, 1, 0, 0)
+				c[k] = grad(hash, 0, y-dy, z-dz)
+			}
+		}
+
+		x1 := x - 1
+		row[i] = num.UnitLerp(w,
+			num.UnitLerp(v,
+				num.UnitLerp(u, a[0]*x+c[0], a[1]*x1+c[1]),
+				num.UnitLerp(u, a[2]*x+c[2], a[3]*x1+c[3])),
+			num.UnitLerp(v,
+				num.UnitLerp(u, a[4]*x+c[4], a[5]*x1+c[5]),
+				num.UnitLerp(u, a[6]*x+c[6], a[7]*x1+c[7])))
+	}
+}
@@ -0,0 +1,141 @@
+package rand
+
+import "testing"
+
+// origins and steps to test grids with, including negative coordinates and
+// steps larger than a cell.
+var gridTests = []struct {
+	name   string
+	origin [3]float64
+	step   float64
+}{
+	{name: "positive", origin: [3]float64{0.5, 1.25, 3}, step: 0.07},
+	{name: "negative", origin: [3]float64{-3.3, -2.1, -0.4}, step: 0.13},
+	{name: "coarse", origin: [3]float64{-10, 4, 2.5}, step: 1.7},
+	{name: "backwards", origin: [3]float64{5, 5, 5}, step: -0.3},
+}
+
+// checks that dst matches noise at every point of a grid.
+func checkGrid3D(t *testing.T, dst []float64, w, h, d int, origin [3]float64, step float64, noise func(x, y, z float64) float64) {
+	t.Helper()
+	for k := 0; k < d; k++ {
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				x := origin[0] + float64(i)*step
+				y := origin[1] + float64(j)*step
+				z := origin[2] + float64(k)*step
+				if got, want := dst[(k*h+j)*w+i], noise(x, y, z); got != want {
+					t.Fatalf("at (%d,%d,%d) got %g, want %g", i, j, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFillGrid(t *testing.T) {
+	const w, h, d = 37, 23, 5
+	FillPermutation(1)
+	perlin := NewPerlin(2)
+	w2 := NewWorley2D(3, 3, 9, MetricEuclidean)
+	w3 := NewWorley3D(4, 2, 9, MetricManhattan)
+	dst := make([]float64, w*h*d)
+
+	for _, tt := range gridTests {
+		origin2 := [2]float64{tt.origin[0], tt.origin[1]}
+		t.Run(tt.name, func(t *testing.T) {
+			FillGrid2D(dst, w, h, origin2, tt.step)
+			checkGrid3D(t, dst, w, h, 1, tt.origin, tt.step, func(x, y, z float64) float64 { return Noise2(x, y) })
+
+			FillGrid3D(dst, w, h, d, tt.origin, tt.step)
+			checkGrid3D(t, dst, w, h, d, tt.origin, tt.step, Noise3)
+
+			perlin.FillGrid2D(dst, w, h, origin2, tt.step)
+			checkGrid3D(t, dst, w, h, 1, tt.origin, tt.step, func(x, y, z float64) float64 { return perlin.Noise(x, y, 0) })
+
+			perlin.FillGrid3D(dst, w, h, d, tt.origin, tt.step)
+			checkGrid3D(t, dst, w, h, d, tt.origin, tt.step, perlin.Noise)
+
+			w2.FillGrid2D(dst, w, h, origin2, tt.step)
+			checkGrid3D(t, dst, w, h, 1, tt.origin, tt.step, func(x, y, z float64) float64 { return w2.Noise(x, y) })
+
+			w3.FillGrid3D(dst, w, h, d, tt.origin, tt.step)
+			checkGrid3D(t, dst, w, h, d, tt.origin, tt.step, w3.Noise)
+		})
+	}
+}
+
+func TestFillGrid_TooSmall(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	FillGrid3D(make([]float64, 10), 2, 2, 3, [3]float64{}, 1)
+}
+
+// grid size and spacing used in the benchmarks, similar to the perlin example.
+const (
+	benchGridW    = 256
+	benchGridH    = 256
+	benchGridStep = 0.02
+)
+
+func BenchmarkPerlin_PerPoint(b *testing.B) {
+	n := NewPerlin(1)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < benchGridH; y++ {
+			for x := 0; x < benchGridW; x++ {
+				dst[y*benchGridW+x] = n.Noise(float64(x)*benchGridStep, float64(y)*benchGridStep, 0.5)
+			}
+		}
+	}
+}
+
+func BenchmarkPerlin_FillGrid3D(b *testing.B) {
+	n := NewPerlin(1)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		n.FillGrid3D(dst, benchGridW, benchGridH, 1, [3]float64{0, 0, 0.5}, benchGridStep)
+	}
+}
+
+func BenchmarkWorley2D_PerPoint(b *testing.B) {
+	n := NewWorley2D(1, 4, 9, MetricEuclidean)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < benchGridH; y++ {
+			for x := 0; x < benchGridW; x++ {
+				dst[y*benchGridW+x] = n.Noise(float64(x)*benchGridStep, float64(y)*benchGridStep)
+			}
+		}
+	}
+}
+
+func BenchmarkWorley2D_FillGrid2D(b *testing.B) {
+	n := NewWorley2D(1, 4, 9, MetricEuclidean)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		n.FillGrid2D(dst, benchGridW, benchGridH, [2]float64{}, benchGridStep)
+	}
+}
+
+func BenchmarkWorley3D_PerPoint(b *testing.B) {
+	n := NewWorley3D(1, 4, 9, MetricEuclidean)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < benchGridH; y++ {
+			for x := 0; x < benchGridW; x++ {
+				dst[y*benchGridW+x] = n.Noise(float64(x)*benchGridStep, float64(y)*benchGridStep, 0.5)
+			}
+		}
+	}
+}
+
+func BenchmarkWorley3D_FillGrid3D(b *testing.B) {
+	n := NewWorley3D(1, 4, 9, MetricEuclidean)
+	dst := make([]float64, benchGridW*benchGridH)
+	for i := 0; i < b.N; i++ {
+		n.FillGrid3D(dst, benchGridW, benchGridH, 1, [3]float64{0, 0, 0.5}, benchGridStep)
+	}
+}
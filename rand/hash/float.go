@@ -0,0 +1,34 @@
+package hash
+
+import "math/bits"
+
+// Float64 converts the hash h to a float64 in [0,1).
+func Float64(h uint32) float64 {
+	return float64(h) / (1 << 32)
+}
+
+// Float32 converts the hash h to a float32 in [0,1), using its top 24 bits.
+func Float32(h uint32) float32 {
+	return float32(h>>8) / (1 << 24)
+}
+
+// Signed converts the hash h to a float64 in [-1,1).
+func Signed(h uint32) float64 {
+	return 2*Float64(h) - 1
+}
+
+// Range converts the hash h to a float64 in [low,high).
+func Range(h uint32, low, high float64) float64 {
+	return low + Float64(h)*(high-low)
+}
+
+// Intn converts the hash h to an int in [0,n), by scaling rather than
+// taking the remainder. Some values are 1 more likely than others out of
+// 2^32, which doesn't matter for small n. Returns 0 if n <= 0.
+func Intn(h uint32, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	hi, _ := bits.Mul64(uint64(h)<<32, uint64(n))
+	return int(hi)
+}
@@ -0,0 +1,143 @@
+// Package hash provides fast, seeded hash functions of 1 to 4 integer
+// coordinates, so that procedural code can get random numbers for a cell,
+// tile or lattice point without keeping a permutation table or any other
+// state.
+//
+// There are 3 families:
+//   - Squirrel, Squirrel Eiserloh's "Squirrel3" noise function, which is the
+//     fastest but mixes its input poorly
+//   - PCG, the hash version of the PCG random number generator, which mixes
+//     well, with one round per coordinate
+//   - XX, xxHash32 of the coordinates, which mixes best and is usually a
+//     good default
+//
+// Each takes the coordinates and a seed and returns 32 random bits, which
+// can be turned into numbers with Float64, Signed, etc.
+package hash
+
+import "math/bits"
+
+// Squirrel3 constants.
+const (
+	squirrelNoise1 = 0xB5297A4D
+	squirrelNoise2 = 0x68E31DA4
+	squirrelNoise3 = 0x1B56C4E9
+
+	// large primes with non-boring bits, used to combine coordinates
+	squirrelPrimeY = 198491317
+	squirrelPrimeZ = 6542989
+	squirrelPrimeW = 357239
+)
+
+// Squirrel1 hashes x with the seed using Squirrel Eiserloh's Squirrel3.
+// It's very fast, but it mixes poorly: some input bits never change some
+// output bits. Use PCG1 or XX1 where that matters.
+//
+// See: "Noise-Based RNG", GDC 2017 (S. Eiserloh)
+func Squirrel1(x int, seed uint32) uint32 {
+	h := uint32(x)
+	h *= squirrelNoise1
+	h += seed
+	h ^= h >> 8
+	h += squirrelNoise2
+	h ^= h << 8
+	h *= squirrelNoise3
+	h ^= h >> 8
+	return h
+}
+
+// Squirrel2 hashes (x, y) with the seed. See Squirrel1.
+func Squirrel2(x, y int, seed uint32) uint32 {
+	return Squirrel1(x+squirrelPrimeY*y, seed)
+}
+
+// Squirrel3 hashes (x, y, z) with the seed. See Squirrel1.
+func Squirrel3(x, y, z int, seed uint32) uint32 {
+	return Squirrel1(x+squirrelPrimeY*y+squirrelPrimeZ*z, seed)
+}
+
+// Squirrel4 hashes (x, y, z, w) with the seed. See Squirrel1.
+func Squirrel4(x, y, z, w int, seed uint32) uint32 {
+	return Squirrel1(x+squirrelPrimeY*y+squirrelPrimeZ*z+squirrelPrimeW*w, seed)
+}
+
+// pcg does one round of the PCG hash.
+// See: "Hash Functions for GPU Rendering" (Jarzynski & Olano, 2020)
+func pcg(v uint32) uint32 {
+	state := v*747796405 + 2891336453
+	word := ((state >> ((state >> 28) + 4)) ^ state) * 277803737
+	return (word >> 22) ^ word
+}
+
+// PCG1 hashes x with the seed using the PCG hash, a permuted congruential
+// generator used as a hash. Coordinates are combined by nesting, as in
+// pcg(y + pcg(x + pcg(seed))).
+//
+// See: "Hash Functions for GPU Rendering" (Jarzynski & Olano, 2020)
+func PCG1(x int, seed uint32) uint32 {
+	return pcg(uint32(x) + pcg(seed))
+}
+
+// PCG2 hashes (x, y) with the seed. See PCG1.
+func PCG2(x, y int, seed uint32) uint32 {
+	return pcg(uint32(y) + PCG1(x, seed))
+}
+
+// PCG3 hashes (x, y, z) with the seed. See PCG1.
+func PCG3(x, y, z int, seed uint32) uint32 {
+	return pcg(uint32(z) + PCG2(x, y, seed))
+}
+
+// PCG4 hashes (x, y, z, w) with the seed. See PCG1.
+func PCG4(x, y, z, w int, seed uint32) uint32 {
+	return pcg(uint32(w) + PCG3(x, y, z, seed))
+}
+
+// xxHash32 constants.
+const (
+	xxPrime1 = 0x9E3779B1
+	xxPrime2 = 0x85EBCA77
+	xxPrime3 = 0xC2B2AE3D
+	xxPrime4 = 0x27D4EB2F
+	xxPrime5 = 0x165667B1
+)
+
+// xxHash32 of the little endian bytes of words, which must be fewer than 4
+// (16 bytes) since the striped loop for longer inputs isn't needed.
+// See: https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md
+func xx(seed uint32, words ...uint32) uint32 {
+	h := seed + xxPrime5 + uint32(4*len(words))
+	for _, k := range words {
+		h += k * xxPrime3
+		h = bits.RotateLeft32(h, 17) * xxPrime4
+	}
+	h ^= h >> 15
+	h *= xxPrime2
+	h ^= h >> 13
+	h *= xxPrime3
+	h ^= h >> 16
+	return h
+}
+
+// XX1 hashes x with the seed. It's the same as xxHash32 of x as 4 little
+// endian bytes, and is the slowest but best mixed of the hashes.
+func XX1(x int, seed uint32) uint32 {
+	return xx(seed, uint32(x))
+}
+
+// XX2 hashes (x, y) with the seed. See XX1.
+func XX2(x, y int, seed uint32) uint32 {
+	return xx(seed, uint32(x), uint32(y))
+}
+
+// XX3 hashes (x, y, z) with the seed. See XX1.
+func XX3(x, y, z int, seed uint32) uint32 {
+	return xx(seed, uint32(x), uint32(y), uint32(z))
+}
+
+// XX4 hashes (x, y, z, w) with the seed. Since 16 bytes is the size where
+// xxHash32 switches to its striped loop, this isn't the same as xxHash32 of
+// the 16 bytes, but it is just as well mixed.
+func XX4(x, y, z, w int, seed uint32) uint32 {
+	return xx(seed, uint32(x), uint32(y), uint32(z), uint32(w))
+}
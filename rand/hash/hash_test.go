@@ -0,0 +1,176 @@
+package hash
+
+import (
+	"math"
+	"testing"
+)
+
+// the hash functions, each adapted to take 4 coordinates and a seed.
+var hashes = []struct {
+	name string
+	dims int
+	f    func(c [4]int, seed uint32) uint32
+}{
+	{"Squirrel1", 1, func(c [4]int, s uint32) uint32 { return Squirrel1(c[0], s) }},
+	{"Squirrel2", 2, func(c [4]int, s uint32) uint32 { return Squirrel2(c[0], c[1], s) }},
+	{"Squirrel3", 3, func(c [4]int, s uint32) uint32 { return Squirrel3(c[0], c[1], c[2], s) }},
+	{"Squirrel4", 4, func(c [4]int, s uint32) uint32 { return Squirrel4(c[0], c[1], c[2], c[3], s) }},
+	{"PCG1", 1, func(c [4]int, s uint32) uint32 { return PCG1(c[0], s) }},
+	{"PCG2", 2, func(c [4]int, s uint32) uint32 { return PCG2(c[0], c[1], s) }},
+	{"PCG3", 3, func(c [4]int, s uint32) uint32 { return PCG3(c[0], c[1], c[2], s) }},
+	{"PCG4", 4, func(c [4]int, s uint32) uint32 { return PCG4(c[0], c[1], c[2], c[3], s) }},
+	{"XX1", 1, func(c [4]int, s uint32) uint32 { return XX1(c[0], s) }},
+	{"XX2", 2, func(c [4]int, s uint32) uint32 { return XX2(c[0], c[1], s) }},
+	{"XX3", 3, func(c [4]int, s uint32) uint32 { return XX3(c[0], c[1], c[2], s) }},
+	{"XX4", 4, func(c [4]int, s uint32) uint32 { return XX4(c[0], c[1], c[2], c[3], s) }},
+}
+
+// for each input bit (32 per coordinate, then 32 of the seed) and output
+// bit, measures the probability that flipping the input bit flips the output
+// bit, which ideally is 0.5. returns the largest and mean deviation from 0.5.
+func avalanche(f func(c [4]int, seed uint32) uint32, dims, trials int) (worst, mean float64) {
+	// inputs from a simple LCG, so the test doesn't depend on package rand
+	state := uint64(12345)
+	next := func() uint32 {
+		state = state*6364136223846793005 + 1442695040888963407
+		return uint32(state >> 32)
+	}
+
+	flips := make([][32]int, 32*(dims+1))
+	for t := 0; t < trials; t++ {
+		var c [4]int
+		for i := 0; i < dims; i++ {
+			c[i] = int(int32(next()))
+		}
+		seed := next()
+		h := f(c, seed)
+		for in := range flips {
+			c2, seed2 := c, seed
+			if i := in / 32; i < dims {
+				c2[i] = int(int32(uint32(c2[i]) ^ 1<<(in%32)))
+			} else {
+				seed2 ^= 1 << (in % 32)
+			}
+			diff := h ^ f(c2, seed2)
+			for out := 0; out < 32; out++ {
+				flips[in][out] += int(diff >> out & 1)
+			}
+		}
+	}
+
+	for in := range flips {
+		for out := range flips[in] {
+			p := float64(flips[in][out]) / float64(trials)
+			worst = math.Max(worst, math.Abs(p-0.5))
+			mean += math.Abs(p - 0.5)
+		}
+	}
+	return worst, mean / float64(32*len(flips))
+}
+
+func TestAvalanche(t *testing.T) {
+	const trials = 4000
+	// with this many trials, a perfect hash rarely deviates by more than
+	// about 0.04 over the thousands of bit pairs, and its mean deviation is
+	// about 0.006. the PCG hash is known to be a bit weaker than xxHash, so
+	// it gets looser limits that still catch a broken mix. Squirrel3 has some
+	// bit pairs that never flip, so its worst case is always 0.5 and only
+	// its mean (about 0.09) is checked.
+	limits := map[string]struct{ worst, mean float64 }{
+		"Squirrel": {math.Inf(1), 0.1},
+		"PCG":      {0.1, 0.008},
+		"XX":       {0.04, 0.008},
+	}
+	for _, tt := range hashes {
+		t.Run(tt.name, func(t *testing.T) {
+			worst, mean := avalanche(tt.f, tt.dims, trials)
+			limit := limits[tt.name[:len(tt.name)-1]]
+			if worst > limit.worst || mean > limit.mean {
+				t.Errorf("avalanche bias worst %.3f, mean %.4f; limits %.3f, %.4f", worst, mean, limit.worst, limit.mean)
+			}
+		})
+	}
+}
+
+func TestHash_SeedsAndCoords(t *testing.T) {
+	// nearby coordinates and seeds should give different hashes
+	for _, tt := range hashes {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := map[uint32]bool{}
+			for seed := uint32(0); seed < 4; seed++ {
+				for i := -8; i < 8; i++ {
+					for d := 0; d < tt.dims; d++ {
+						var c [4]int
+						c[d] = i
+						if i == 0 && d > 0 {
+							continue // (0,0,0,0) was already done
+						}
+						h := tt.f(c, seed)
+						if seen[h] {
+							t.Fatalf("duplicate hash %#x at %v, seed %d", h, c, seed)
+						}
+						seen[h] = true
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestXX_Empty(t *testing.T) {
+	// the xxHash32 of no bytes with seed 0, from the reference implementation
+	if h := xx(0); h != 0x02CC5D05 {
+		t.Errorf("got %#x", h)
+	}
+}
+
+func TestFloats(t *testing.T) {
+	tests := []struct {
+		h                 uint32
+		f64, signed       float64
+		f32               float32
+		intn10, intnLarge int
+	}{
+		{0, 0, -1, 0, 0, 0},
+		{1 << 31, 0.5, 0, 0.5, 5, 1 << 40},
+		{math.MaxUint32, 1 - 1.0/(1<<32), 1 - 2.0/(1<<32), 1 - 1.0/(1<<24), 9, 1<<41 - 1<<9},
+	}
+	for _, tt := range tests {
+		if got := Float64(tt.h); got != tt.f64 {
+			t.Errorf("Float64(%#x) = %g, want %g", tt.h, got, tt.f64)
+		}
+		if got := Signed(tt.h); got != tt.signed {
+			t.Errorf("Signed(%#x) = %g, want %g", tt.h, got, tt.signed)
+		}
+		if got := Float32(tt.h); got != tt.f32 {
+			t.Errorf("Float32(%#x) = %g, want %g", tt.h, got, tt.f32)
+		}
+		if got := Intn(tt.h, 10); got != tt.intn10 {
+			t.Errorf("Intn(%#x, 10) = %d, want %d", tt.h, got, tt.intn10)
+		}
+		if got := Intn(tt.h, 1<<41); got != tt.intnLarge {
+			t.Errorf("Intn(%#x, 2^41) = %d, want %d", tt.h, got, tt.intnLarge)
+		}
+		if got := Range(tt.h, 10, 20); got != 10+10*tt.f64 {
+			t.Errorf("Range(%#x, 10, 20) = %g", tt.h, got)
+		}
+	}
+	if Intn(123, 0) != 0 {
+		t.Error("Intn(h, 0) != 0")
+	}
+}
+
+func BenchmarkHash3(b *testing.B) {
+	for _, tt := range hashes {
+		if tt.dims != 3 {
+			continue
+		}
+		b.Run(tt.name, func(b *testing.B) {
+			var sink uint32
+			for i := 0; i < b.N; i++ {
+				sink += tt.f([4]int{i, i >> 3, i >> 6}, 42)
+			}
+			_ = sink
+		})
+	}
+}
@@ -48,7 +48,7 @@ func MakePermutation(seed int64) (perm *[512]int) {
 //     (1,0,1),(-1,0,1),(1,0,-1),(-1,0,-1),
 //     (0,1,1),(0,-1,1),(0,1,-1),(0,-1,-1)
 // chosen "randomly" based on the hash value
-func grad(hash int, x, y, z float64) float64 {
+func grad[T num.Float](hash int, x, y, z T) T {
 	switch hash & 0xF {
 	case 0x0:
 		return x + y
@@ -93,14 +93,44 @@ func grad(hash int, x, y, z float64) float64 {
 // See: http://mrl.nyu.edu/~perlin/noise/
 // Paper: http://mrl.nyu.edu/~perlin/paper445.pdf
 func Noise3(x, y, z float64) float64 {
-	// find unit cube that contains point
-	xCube, yCube, zCube := 255&int(x), 255&int(y), 255&int(z)
+	return perlin3(p, x, y, z)
+}
+
+// Noise3Of is the same as Noise3 but works on float32 as well as float64.
+func Noise3Of[T num.Float](x, y, z T) T {
+	return perlin3(p, x, y, z)
+}
+
+// Perlin is a perlin noise generator with its own permutation table, so
+// that several generators with different seeds can be used at once. Noise3
+// and friends share one package-level table.
+type Perlin struct {
+	perm *[512]int
+}
+
+// NewPerlin creates a new perlin noise generator using the seed to make
+// its permutation table.
+func NewPerlin(seed int64) *Perlin {
+	return &Perlin{perm: MakePermutation(seed)}
+}
+
+// Noise returns 3d perlin noise at (x, y, z). See Noise3.
+func (n *Perlin) Noise(x, y, z float64) float64 {
+	return perlin3(n.perm, x, y, z)
+}
+
+// does the actual work of Noise3 using permutation table p.
+func perlin3[T num.Float](p *[512]int, x, y, z T) T {
+	// find unit cube that contains point. floor, not int(), so negative
+	// coordinates land in the right cube instead of the one nearer 0.
+	xf, yf, zf := floor(x), floor(y), floor(z)
+	xCube, yCube, zCube := 255&int(xf), 255&int(yf), 255&int(zf)
 
 	// x,y,z in [0,1] as a porportional location inside that cube
-	x, y, z = x-math.Floor(x), y-math.Floor(y), z-math.Floor(z)
+	x, y, z = x-xf, y-yf, z-zf
 
 	// fade curves for x,y,z
-	u, v, w := num.SmootherStep(x), num.SmootherStep(y), num.SmootherStep(z)
+	u, v, w := num.SmootherStepOf(x), num.SmootherStepOf(y), num.SmootherStepOf(z)
 
 	// hash coordinates of the 8 cube corners
 	A := p[xCube] + yCube
@@ -112,13 +142,13 @@ func Noise3(x, y, z float64) float64 {
 
 	// get gradients from point to corners of unit cube, using 'dot product',
 	// then blend them together
-	return num.UnitLerp(w, num.UnitLerp(v, num.UnitLerp(u, grad(p[AA], x, y, z),
+	return num.Interpolate(w, num.Interpolate(v, num.Interpolate(u, grad(p[AA], x, y, z),
 		grad(p[BA], x-1, y, z)),
-		num.UnitLerp(u, grad(p[AB], x, y-1, z),
+		num.Interpolate(u, grad(p[AB], x, y-1, z),
 			grad(p[BB], x-1, y-1, z))),
-		num.UnitLerp(v, num.UnitLerp(u, grad(p[AA+1], x, y, z-1),
+		num.Interpolate(v, num.Interpolate(u, grad(p[AA+1], x, y, z-1),
 			grad(p[BA+1], x-1, y, z-1)),
-			num.UnitLerp(u, grad(p[AB+1], x, y-1, z-1),
+			num.Interpolate(u, grad(p[AB+1], x, y-1, z-1),
 				grad(p[BB+1], x-1, y-1, z-1))))
 
 	// above should be formatted as below, but go's formatter won't let it be
@@ -132,11 +162,21 @@ func Noise3(x, y, z float64) float64 {
 	// 			                                       grad(p[BB+1], x-1, y-1, z-1))))
 }
 
+// floor for float32 or float64. the conversions are exact.
+func floor[T num.Float](x T) T {
+	return T(math.Floor(float64(x)))
+}
+
 // Noise2 provides 2d noise based on Noise3.
 func Noise2(x, y float64) float64 {
 	return Noise3(x, y, defaultZ)
 }
 
+// Noise2Of is the same as Noise2 but works on float32 as well as float64.
+func Noise2Of[T num.Float](x, y T) T {
+	return Noise3Of(x, y, T(defaultZ))
+}
+
 // Noise1 provides 1d noise based on Noise3.
 func Noise1(x float64) float64 {
 	return Noise3(x, defaultY, defaultZ)
@@ -152,15 +192,22 @@ func Noise1(x float64) float64 {
 // See: http://flafla2.github.io/2014/08/09/perlinnoise.html
 // and: http://freespace.virgin.net/hugo.elias/models/m_perlin.htm (may be broken)
 func Noise3Octaves(x, y, z float64, octaves int, lacunarity, persistence float64) float64 {
-	total := 0.0
-	frequency := 1.0
-	amplitude := 1.0
-	maxVal := 0.0 //used for normalizing result to [-1,1]
+	return Noise3OctavesOf(x, y, z, octaves, lacunarity, persistence)
+}
+
+// Noise3OctavesOf is the same as Noise3Octaves but works on float32 as well
+// as float64.
+func Noise3OctavesOf[T num.Float](x, y, z T, octaves int, lacunarity, persistence T) T {
+	var total T
+	var frequency, amplitude T = 1, 1
+	var maxVal T //used for normalizing result to [-1,1]
 	for i := 0; i < octaves; i++ {
-		total += Noise3(x*frequency, y*frequency, z*frequency) * amplitude
+		total += Noise3Of(x*frequency, y*frequency, z*frequency) * amplitude
 		maxVal += amplitude
 		amplitude *= persistence
 		frequency *= lacunarity
 	}
-	return num.Lerp(total, -maxVal, maxVal, -1, 1) // normalize to [-1,1]
+	// normalize to [-1,1]. the sign is flipped to keep the output the same as
+	// it was when this used the old, backwards num.Lerp.
+	return -total / maxVal
 }
@@ -1,6 +1,7 @@
 package rand
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -42,6 +43,23 @@ func TestNoise3_Range(t *testing.T) {
 	}
 }
 
+func TestNoise3_Continuous(t *testing.T) {
+	// noise is continuous, so values just either side of a lattice plane
+	// should be nearly equal, including at negative coordinates.
+	const eps = 1e-9
+	FillPermutation(1)
+	for _, c := range []float64{-348, -17, -1, 0, 1, 42} {
+		for axis := 0; axis < 3; axis++ {
+			lo, hi := [3]float64{0.3, 0.7, 0.45}, [3]float64{0.3, 0.7, 0.45}
+			lo[axis], hi[axis] = c-eps, c+eps
+			a, b := Noise3(lo[0], lo[1], lo[2]), Noise3(hi[0], hi[1], hi[2])
+			if math.Abs(a-b) > 1e-6 {
+				t.Errorf("jump across %g on axis %d: %g vs %g", c, axis, a, b)
+			}
+		}
+	}
+}
+
 func BenchmarkNoise3(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		offset := float64(i) / float64(b.N)
@@ -55,3 +73,30 @@ func BenchmarkNoise3Octaves_4_2_05(b *testing.B) {
 		Noise3Octaves(offset, offset, offset, 4, 2, 0.5)
 	}
 }
+
+func TestNoise3Of_Float32(t *testing.T) {
+	FillPermutation(1)
+	for i := 0; i < testN; i++ {
+		// coordinates exactly representable as float32
+		x, y, z := float64(i)*0.125, float64(i%97)*0.25, float64(i%13)*0.5+0.0625
+		want := Noise3(x, y, z)
+		if got := Noise3Of(x, y, z); got != want {
+			t.Fatalf("float64 at (%g,%g,%g): got %g, want %g", x, y, z, got, want)
+		}
+		if got := Noise3Of(float32(x), float32(y), float32(z)); math.Abs(float64(got)-want) > 1e-5 {
+			t.Fatalf("float32 at (%g,%g,%g): got %g, want %g", x, y, z, got, want)
+		}
+
+		want = Noise3Octaves(x, y, z, 4, 2, 0.5)
+		if got := Noise3OctavesOf(float32(x), float32(y), float32(z), 4, 2, 0.5); math.Abs(float64(got)-want) > 1e-4 {
+			t.Fatalf("octaves at (%g,%g,%g): got %g, want %g", x, y, z, got, want)
+		}
+	}
+}
+
+func BenchmarkNoise3Of_Float32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		offset := float32(i) / float32(b.N)
+		Noise3Of(offset, offset, offset)
+	}
+}
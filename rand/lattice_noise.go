@@ -0,0 +1,95 @@
+package rand
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/num"
+)
+
+// ValueNoise is 3D value noise: a random value is assigned to each integer
+// lattice point and blended across the cells with Fade. It is cheaper than
+// gradient noise but has more visible grid artifacts.
+//
+// Fade is an interpolant that maps [0,1] to [0,1] and decides the smoothness
+// of the result, eg num.SmoothStep, num.SmootherStep, num.SmoothestStep or
+// num.CosineStep. A function returning its argument gives plain
+// (discontinuous derivative) linear blending.
+type ValueNoise struct {
+	Fade func(float64) float64
+	perm *[512]int
+}
+
+// NewValueNoise creates a value noise generator using the seed to make its
+// permutation table. If fade is nil, num.SmootherStep is used.
+func NewValueNoise(seed int64, fade func(float64) float64) *ValueNoise {
+	if fade == nil {
+		fade = num.SmootherStep
+	}
+	return &ValueNoise{Fade: fade, perm: MakePermutation(seed)}
+}
+
+// Noise gets the value noise at (x, y, z), in [-1,1].
+func (n *ValueNoise) Noise(x, y, z float64) float64 {
+	h, x, y, z := latticeCell(n.perm, x, y, z)
+	var c [8]float64
+	for i := range c {
+		c[i] = float64(h[i])/127.5 - 1 // [0,255] to [-1,1]
+	}
+	return trilerp(n.Fade(x), n.Fade(y), n.Fade(z), &c)
+}
+
+// GradientNoise is 3D gradient noise: a pseudo-random gradient is assigned
+// to each integer lattice point and the dot products with the offset to
+// each corner are blended across the cells with Fade. With num.SmootherStep
+// it is the same as Perlin's improved noise (Perlin and Noise3). See
+// ValueNoise for the choice of Fade.
+type GradientNoise struct {
+	Fade func(float64) float64
+	perm *[512]int
+}
+
+// NewGradientNoise creates a gradient noise generator using the seed to make
+// its permutation table. If fade is nil, num.SmootherStep is used.
+func NewGradientNoise(seed int64, fade func(float64) float64) *GradientNoise {
+	if fade == nil {
+		fade = num.SmootherStep
+	}
+	return &GradientNoise{Fade: fade, perm: MakePermutation(seed)}
+}
+
+// Noise gets the gradient noise at (x, y, z), in about [-1,1].
+func (n *GradientNoise) Noise(x, y, z float64) float64 {
+	h, x, y, z := latticeCell(n.perm, x, y, z)
+	var c [8]float64
+	for i := range c {
+		dx, dy, dz := float64(i&1), float64(i>>1&1), float64(i>>2&1)
+		c[i] = grad(h[i], x-dx, y-dy, z-dz)
+	}
+	return trilerp(n.Fade(x), n.Fade(y), n.Fade(z), &c)
+}
+
+// finds the lattice cell containing (x,y,z) and hashes its 8 corners with the
+// permutation table. the corner at offset (i,j,k) is at index i | j<<1 | k<<2.
+// also returns the position of the point inside the cell, in [0,1).
+func latticeCell(p *[512]int, x, y, z float64) (h [8]int, fx, fy, fz float64) {
+	x0, y0, z0 := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(x0)&255, int(y0)&255, int(z0)&255
+
+	A := p[xi] + yi
+	B := p[xi+1] + yi
+	AA, AB := p[A]+zi, p[A+1]+zi
+	BA, BB := p[B]+zi, p[B+1]+zi
+	h = [8]int{
+		p[AA], p[BA], p[AB], p[BB],
+		p[AA+1], p[BA+1], p[AB+1], p[BB+1],
+	}
+	return h, x - x0, y - y0, z - z0
+}
+
+// blends the 8 corner values c (ordered as in latticeCell) with the weights
+// u, v, w along x, y and z.
+func trilerp(u, v, w float64, c *[8]float64) float64 {
+	return num.UnitLerp(w,
+		num.UnitLerp(v, num.UnitLerp(u, c[0], c[1]), num.UnitLerp(u, c[2], c[3])),
+		num.UnitLerp(v, num.UnitLerp(u, c[4], c[5]), num.UnitLerp(u, c[6], c[7])))
+}
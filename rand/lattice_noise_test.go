@@ -0,0 +1,96 @@
+package rand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quillaja/goutil/num"
+)
+
+// interpolants to test lattice noise with.
+var fades = []struct {
+	name string
+	fade func(float64) float64
+}{
+	{"linear", func(t float64) float64 { return t }},
+	{"cosine", num.CosineStep},
+	{"smooth", num.SmoothStep},
+	{"smoother", num.SmootherStep},
+	{"smoothest", num.SmoothestStep},
+}
+
+func TestGradientNoise_MatchesPerlin(t *testing.T) {
+	p := NewPerlin(7)
+	g := NewGradientNoise(7, num.SmootherStep)
+	for i := -testN / 2; i < testN/2; i++ {
+		x, y, z := float64(i)*0.137, float64(i)*0.071, float64(i)*0.013
+		if want, got := p.Noise(x, y, z), g.Noise(x, y, z); !nearlyEqual(got, want) {
+			t.Fatalf("at (%g,%g,%g) got %g, want %g", x, y, z, got, want)
+		}
+	}
+}
+
+func TestLatticeNoise_Lattice(t *testing.T) {
+	// gradient noise is 0 at lattice points, and value noise gives the
+	// corner value regardless of the fade.
+	for _, tt := range fades {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGradientNoise(3, tt.fade)
+			v := NewValueNoise(3, tt.fade)
+			ref := NewValueNoise(3, num.SmoothStep)
+			for x := -4.0; x <= 4; x++ {
+				for y := -4.0; y <= 4; y++ {
+					if got := g.Noise(x, y, 2); got != 0 {
+						t.Errorf("gradient at (%g,%g,2) = %g, want 0", x, y, got)
+					}
+					if got, want := v.Noise(x, y, 2), ref.Noise(x, y, 2); got != want {
+						t.Errorf("value at (%g,%g,2) = %g, want %g", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLatticeNoise_RangeAndContinuity(t *testing.T) {
+	const step = 1e-6
+	for _, tt := range fades {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, n := range []Noise3D{NewValueNoise(5, tt.fade), NewGradientNoise(5, tt.fade)} {
+				for i := 0; i < testN; i++ {
+					// include negative coordinates and cell boundaries
+					x, y, z := float64(i)*0.25-50, float64(i)*0.071-20, float64(i)*0.5
+					a, b := n.Noise(x, y, z), n.Noise(x+step, y+step, z+step)
+					if a < -1 || 1 < a || math.IsNaN(a) {
+						t.Fatalf("%T: %g not in [-1,1] at (%g,%g,%g)", n, a, x, y, z)
+					}
+					if math.Abs(a-b) > 1e-4 {
+						t.Fatalf("%T: discontinuity at (%g,%g,%g): %g, %g", n, x, y, z, a, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkValueNoise(b *testing.B) {
+	for _, tt := range fades {
+		b.Run(tt.name, func(b *testing.B) {
+			n := NewValueNoise(1, tt.fade)
+			for i := 0; i < b.N; i++ {
+				n.Noise(float64(i)*0.137, 1.3, 2.7)
+			}
+		})
+	}
+}
+
+func BenchmarkGradientNoise(b *testing.B) {
+	for _, tt := range fades {
+		b.Run(tt.name, func(b *testing.B) {
+			n := NewGradientNoise(1, tt.fade)
+			for i := 0; i < b.N; i++ {
+				n.Noise(float64(i)*0.137, 1.3, 2.7)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package module
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/num"
+)
+
+func init() {
+	register("add", 2, func(s *Spec, src []Module) (Module, error) {
+		return &Add{A: src[0], B: src[1]}, nil
+	})
+	register("multiply", 2, func(s *Spec, src []Module) (Module, error) {
+		return &Multiply{A: src[0], B: src[1]}, nil
+	})
+	register("min", 2, func(s *Spec, src []Module) (Module, error) {
+		return &Min{A: src[0], B: src[1]}, nil
+	})
+	register("max", 2, func(s *Spec, src []Module) (Module, error) {
+		return &Max{A: src[0], B: src[1]}, nil
+	})
+	register("blend", 3, func(s *Spec, src []Module) (Module, error) {
+		return &Blend{A: src[0], B: src[1], Control: src[2]}, nil
+	})
+	register("select", 3, func(s *Spec, src []Module) (Module, error) {
+		return &Select{A: src[0], B: src[1], Control: src[2],
+			Lower:   s.param("lower", -1),
+			Upper:   s.param("upper", 1),
+			Falloff: s.param("falloff", 0)}, nil
+	})
+}
+
+// Add is a combiner module that adds the outputs of A and B.
+type Add struct {
+	A, B Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Add) Noise(x, y, z float64) float64 {
+	return m.A.Noise(x, y, z) + m.B.Noise(x, y, z)
+}
+
+// Spec describes the module.
+func (m *Add) Spec() *Spec {
+	return spec("add", nil, m.A, m.B)
+}
+
+// Multiply is a combiner module that multiplies the outputs of A and B.
+type Multiply struct {
+	A, B Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Multiply) Noise(x, y, z float64) float64 {
+	return m.A.Noise(x, y, z) * m.B.Noise(x, y, z)
+}
+
+// Spec describes the module.
+func (m *Multiply) Spec() *Spec {
+	return spec("multiply", nil, m.A, m.B)
+}
+
+// Min is a combiner module that gives the smaller of the outputs of A and B.
+type Min struct {
+	A, B Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Min) Noise(x, y, z float64) float64 {
+	return math.Min(m.A.Noise(x, y, z), m.B.Noise(x, y, z))
+}
+
+// Spec describes the module.
+func (m *Min) Spec() *Spec {
+	return spec("min", nil, m.A, m.B)
+}
+
+// Max is a combiner module that gives the larger of the outputs of A and B.
+type Max struct {
+	A, B Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Max) Noise(x, y, z float64) float64 {
+	return math.Max(m.A.Noise(x, y, z), m.B.Noise(x, y, z))
+}
+
+// Spec describes the module.
+func (m *Max) Spec() *Spec {
+	return spec("max", nil, m.A, m.B)
+}
+
+// Blend is a combiner module that linearly interpolates between the outputs
+// of A and B, using the output of Control as the weight. A Control output
+// of -1 gives A, and 1 gives B.
+type Blend struct {
+	A, B, Control Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Blend) Noise(x, y, z float64) float64 {
+	t := (m.Control.Noise(x, y, z) + 1) / 2
+	return num.UnitLerp(t, m.A.Noise(x, y, z), m.B.Noise(x, y, z))
+}
+
+// Spec describes the module.
+func (m *Blend) Spec() *Spec {
+	return spec("blend", nil, m.A, m.B, m.Control)
+}
+
+// Select is a combiner module that gives the output of B where the output
+// of Control is in [Lower,Upper], and the output of A everywhere else.
+// If Falloff is greater than 0, A and B are smoothly blended within Falloff
+// of the edges of the range.
+type Select struct {
+	A, B, Control Module
+	Lower, Upper  float64
+	Falloff       float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Select) Noise(x, y, z float64) float64 {
+	c := m.Control.Noise(x, y, z)
+	lo, hi := m.Lower, m.Upper
+	f := math.Min(m.Falloff, (hi-lo)/2)
+
+	if f <= 0 {
+		if lo <= c && c <= hi {
+			return m.B.Noise(x, y, z)
+		}
+		return m.A.Noise(x, y, z)
+	}
+
+	switch {
+	case c < lo-f:
+		return m.A.Noise(x, y, z)
+	case c < lo+f:
+		t := num.SmoothStep((c - (lo - f)) / (2 * f))
+		return num.UnitLerp(t, m.A.Noise(x, y, z), m.B.Noise(x, y, z))
+	case c < hi-f:
+		return m.B.Noise(x, y, z)
+	case c < hi+f:
+		t := num.SmoothStep((c - (hi - f)) / (2 * f))
+		return num.UnitLerp(t, m.B.Noise(x, y, z), m.A.Noise(x, y, z))
+	default:
+		return m.A.Noise(x, y, z)
+	}
+}
+
+// Spec describes the module.
+func (m *Select) Spec() *Spec {
+	return spec("select", map[string]float64{
+		"lower":   m.Lower,
+		"upper":   m.Upper,
+		"falloff": m.Falloff,
+	}, m.A, m.B, m.Control)
+}
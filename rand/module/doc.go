@@ -0,0 +1,18 @@
+// Package module provides a way to build complex noise by connecting
+// simple noise "modules" together into a graph, in the style of libnoise.
+//
+// There are 4 kinds of modules:
+//   - sources, which generate noise (Perlin, Cell, Const)
+//   - modifiers, which change the output of one module (ScaleBias, Clamp,
+//     Curve, Terrace, Abs, Invert)
+//   - combiners, which combine the output of several modules (Add, Multiply,
+//     Min, Max, Blend, Select)
+//   - transformers, which change the input coordinates of one module
+//     (Translate, Scale, Rotate, Turbulence)
+//
+// Every module can describe itself as a Spec, and a graph of Specs can be
+// saved to and loaded from JSON with Marshal and Unmarshal, so that noise
+// can be tweaked without recompiling.
+//
+// See: http://libnoise.sourceforge.net/
+package module
@@ -0,0 +1,200 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/quillaja/goutil/num"
+)
+
+func init() {
+	register("scalebias", 1, func(s *Spec, src []Module) (Module, error) {
+		return &ScaleBias{Source: src[0], Scale: s.param("scale", 1), Bias: s.param("bias", 0)}, nil
+	})
+	register("clamp", 1, func(s *Spec, src []Module) (Module, error) {
+		return &Clamp{Source: src[0], Min: s.param("min", -1), Max: s.param("max", 1)}, nil
+	})
+	register("curve", 1, func(s *Spec, src []Module) (Module, error) {
+		if _, ok := curves[s.Func]; !ok {
+			return nil, fmt.Errorf("curve: unknown curve %q", s.Func)
+		}
+		return NewCurve(src[0], s.Func), nil
+	})
+	register("terrace", 1, func(s *Spec, src []Module) (Module, error) {
+		if len(s.Points) < 2 {
+			return nil, fmt.Errorf("terrace: needs at least 2 points but has %d", len(s.Points))
+		}
+		return NewTerrace(src[0], s.param("invert", 0) != 0, s.Points...), nil
+	})
+	register("abs", 1, func(s *Spec, src []Module) (Module, error) {
+		return &Abs{Source: src[0]}, nil
+	})
+	register("invert", 1, func(s *Spec, src []Module) (Module, error) {
+		return &Invert{Source: src[0]}, nil
+	})
+}
+
+// ScaleBias is a modifier module that multiplies the source's output by
+// Scale then adds Bias.
+type ScaleBias struct {
+	Source      Module
+	Scale, Bias float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *ScaleBias) Noise(x, y, z float64) float64 {
+	return m.Source.Noise(x, y, z)*m.Scale + m.Bias
+}
+
+// Spec describes the module.
+func (m *ScaleBias) Spec() *Spec {
+	return spec("scalebias", map[string]float64{"scale": m.Scale, "bias": m.Bias}, m.Source)
+}
+
+// Clamp is a modifier module that clamps the source's output to [Min,Max].
+type Clamp struct {
+	Source   Module
+	Min, Max float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Clamp) Noise(x, y, z float64) float64 {
+	return num.ClampFloat(m.Source.Noise(x, y, z), m.Min, m.Max)
+}
+
+// Spec describes the module.
+func (m *Clamp) Spec() *Spec {
+	return spec("clamp", map[string]float64{"min": m.Min, "max": m.Max}, m.Source)
+}
+
+// curves available to Curve by name.
+var curves = map[string]func(float64) float64{
+	"linear":        func(x float64) float64 { return x },
+	"smoothstep":    num.SmoothStep,
+	"smootherstep":  num.SmootherStep,
+	"smootheststep": num.SmoothestStep,
+}
+
+// Curve is a modifier module that reshapes the source's output with one
+// of the smoothstep functions from package num. The output is mapped from
+// [-1,1] to [0,1], put through the curve, then mapped back to [-1,1].
+type Curve struct {
+	Source Module
+	// Name is one of "linear", "smoothstep", "smootherstep" or "smootheststep".
+	Name  string
+	curve func(float64) float64
+}
+
+// NewCurve creates a Curve module. Panics if name isn't a known curve.
+func NewCurve(source Module, name string) *Curve {
+	c, ok := curves[name]
+	if !ok {
+		panic(fmt.Errorf("unknown curve %q", name))
+	}
+	return &Curve{Source: source, Name: name, curve: c}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Curve) Noise(x, y, z float64) float64 {
+	t := (m.Source.Noise(x, y, z) + 1) / 2
+	return 2*m.curve(t) - 1
+}
+
+// Spec describes the module.
+func (m *Curve) Spec() *Spec {
+	s := spec("curve", nil, m.Source)
+	s.Func = m.Name
+	return s
+}
+
+// Terrace is a modifier module that maps the source's output onto a curve
+// which flattens out near each control point, giving a terraced look.
+// Outside the first and last control points, output is clamped.
+type Terrace struct {
+	Source Module
+	// Points are the control points, in ascending order.
+	Points []float64
+	// Invert makes the curve flatten in between points rather than at them.
+	Invert bool
+}
+
+// NewTerrace creates a Terrace module. Panics if there are fewer than 2 points.
+func NewTerrace(source Module, invert bool, points ...float64) *Terrace {
+	if len(points) < 2 {
+		panic(fmt.Errorf("Invalid params: terrace needs at least 2 points"))
+	}
+	pts := append([]float64(nil), points...)
+	sort.Float64s(pts)
+	return &Terrace{Source: source, Points: pts, Invert: invert}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Terrace) Noise(x, y, z float64) float64 {
+	v := m.Source.Noise(x, y, z)
+	pts := m.Points
+
+	// find the control points on either side of v
+	i := sort.SearchFloat64s(pts, v)
+	if i == 0 {
+		return pts[0]
+	}
+	if i == len(pts) {
+		return pts[len(pts)-1]
+	}
+	lo, hi := pts[i-1], pts[i]
+	if lo == hi {
+		return lo
+	}
+
+	t := (v - lo) / (hi - lo)
+	if m.Invert {
+		t = 1 - t
+		lo, hi = hi, lo
+	}
+	return num.UnitLerp(t*t, lo, hi)
+}
+
+// Spec describes the module.
+func (m *Terrace) Spec() *Spec {
+	var params map[string]float64
+	if m.Invert {
+		params = map[string]float64{"invert": 1}
+	}
+	s := spec("terrace", params, m.Source)
+	s.Points = m.Points
+	return s
+}
+
+// Abs is a modifier module that gives the absolute value of the source's output.
+type Abs struct {
+	Source Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Abs) Noise(x, y, z float64) float64 {
+	v := m.Source.Noise(x, y, z)
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Spec describes the module.
+func (m *Abs) Spec() *Spec {
+	return spec("abs", nil, m.Source)
+}
+
+// Invert is a modifier module that negates the source's output.
+type Invert struct {
+	Source Module
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Invert) Noise(x, y, z float64) float64 {
+	return -m.Source.Noise(x, y, z)
+}
+
+// Spec describes the module.
+func (m *Invert) Spec() *Spec {
+	return spec("invert", nil, m.Source)
+}
@@ -0,0 +1,105 @@
+package module
+
+import (
+	"reflect"
+	"testing"
+)
+
+// a graph using every type of module
+func testGraph() Module {
+	mountains := &ScaleBias{
+		Source: NewTurbulence(NewRotate(NewPerlin(1), 10, 20, 30), 2, 1, 0.25, 3),
+		Scale:  0.5, Bias: 0.25}
+	plains := NewCurve(&Abs{Source: &Scale{Source: NewPerlin(3), X: 0.5, Y: 0.5, Z: 0.5}}, "smootherstep")
+	craters := &Invert{Source: NewCell(4, 2, 5, "euclidean")}
+	control := &Translate{Source: NewPerlin(5), X: 100, Y: 100}
+
+	terrain := &Select{
+		A: &Add{A: plains, B: &Multiply{A: craters, B: &Const{Value: 0.1}}},
+		B: mountains, Control: control,
+		Lower: 0, Upper: 1, Falloff: 0.1}
+
+	return &Clamp{
+		Source: NewTerrace(&Blend{
+			A:       &Min{A: terrain, B: &Const{Value: 0.9}},
+			B:       &Max{A: terrain, B: &Const{Value: -0.9}},
+			Control: NewPerlin(6),
+		}, false, -1, -0.5, 0, 0.5, 1),
+		Min: -1, Max: 1}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	original := testGraph()
+	js, err := Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Unmarshal(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(original.Spec(), loaded.Spec()) {
+		t.Errorf("specs differ after round trip:\n%s", js)
+	}
+
+	for i := 0; i < 1000; i++ {
+		x, y, z := float64(i%50)*0.137, float64(i/50)*0.071, 0.5
+		a, b := original.Noise(x, y, z), loaded.Noise(x, y, z)
+		if a != b {
+			t.Fatalf("(%g,%g,%g): %g != %g", x, y, z, a, b)
+		}
+		if a < -1 || a > 1 {
+			t.Fatalf("(%g,%g,%g): %g out of range", x, y, z, a)
+		}
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "bad json", json: `{"type":`},
+		{name: "unknown type", json: `{"type":"nope"}`},
+		{name: "missing source", json: `{"type":"add","sources":[{"type":"perlin"}]}`},
+		{name: "bad source", json: `{"type":"abs","sources":[{"type":"nope"}]}`},
+		{name: "unknown curve", json: `{"type":"curve","func":"nope","sources":[{"type":"perlin"}]}`},
+		{name: "unknown metric", json: `{"type":"cell","func":"nope"}`},
+		{name: "few points", json: `{"type":"terrace","points":[1],"sources":[{"type":"perlin"}]}`},
+		{name: "negative max", json: `{"type":"cell","func":"euclidean","params":{"max":-2}}`},
+		{name: "zero max", json: `{"type":"cell","func":"euclidean","params":{"max":0}}`},
+		{name: "huge max", json: `{"type":"cell","func":"euclidean","params":{"max":1e12}}`},
+		{name: "negative lambda", json: `{"type":"cell","func":"euclidean","params":{"lambda":-1}}`},
+		{name: "fractional lambda", json: `{"type":"cell","func":"euclidean","params":{"lambda":2.5}}`},
+		{name: "zero roughness", json: `{"type":"turbulence","params":{"roughness":0},"sources":[{"type":"perlin"}]}`},
+		{name: "huge roughness", json: `{"type":"turbulence","params":{"roughness":1e9},"sources":[{"type":"perlin"}]}`},
+		{name: "bad nested param", json: `{"type":"abs","sources":[{"type":"cell","func":"euclidean","params":{"max":-2}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Unmarshal([]byte(tt.json)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestTerrace(t *testing.T) {
+	tr := NewTerrace(&Const{}, false, 1, -1, 0.5)
+	tests := []struct {
+		in, want float64
+	}{
+		{in: -2, want: -1},
+		{in: -1, want: -1},
+		{in: 0.5, want: 0.5},
+		{in: 2, want: 1},
+		{in: -0.25, want: -0.625}, // t=0.5 between -1 and 0.5, so t*t=0.25
+	}
+	for _, tt := range tests {
+		tr.Source = &Const{Value: tt.in}
+		if got := tr.Noise(0, 0, 0); got != tt.want {
+			t.Errorf("terrace(%g) = %g, want %g", tt.in, got, tt.want)
+		}
+	}
+}
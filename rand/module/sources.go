@@ -0,0 +1,121 @@
+package module
+
+import (
+	"fmt"
+
+	"github.com/quillaja/goutil/data"
+	"github.com/quillaja/goutil/rand"
+)
+
+func init() {
+	register("perlin", 0, func(s *Spec, src []Module) (Module, error) {
+		return NewPerlin(s.Seed), nil
+	})
+	register("cell", 0, func(s *Spec, src []Module) (Module, error) {
+		if _, ok := metrics[s.Func]; !ok {
+			return nil, fmt.Errorf("cell: unknown metric %q", s.Func)
+		}
+		// num.Poisson, used for the number of points in a cell, only
+		// works up to 20.
+		lambda, err := s.intParam("lambda", 2, 0, 20)
+		if err != nil {
+			return nil, err
+		}
+		max, err := s.intParam("max", 5, 1, 20)
+		if err != nil {
+			return nil, err
+		}
+		return NewCell(s.Seed, lambda, max, s.Func), nil
+	})
+	register("const", 0, func(s *Spec, src []Module) (Module, error) {
+		return &Const{Value: s.param("value", 0)}, nil
+	})
+}
+
+// Perlin is a source module that generates perlin noise.
+type Perlin struct {
+	Seed  int64
+	noise *rand.Perlin
+}
+
+// NewPerlin creates a Perlin module.
+func NewPerlin(seed int64) *Perlin {
+	return &Perlin{Seed: seed, noise: rand.NewPerlin(seed)}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Perlin) Noise(x, y, z float64) float64 {
+	return m.noise.Noise(x, y, z)
+}
+
+// Spec describes the module.
+func (m *Perlin) Spec() *Spec {
+	return &Spec{Type: "perlin", Seed: m.Seed}
+}
+
+// distance metrics available to Cell by name.
+var metrics = map[string]data.DistanceMetric{
+	"euclidean":   data.Euclidean,
+	"euclideansq": data.EuclideanSq,
+	"manhattan":   data.Manhattan,
+	"chebyshev":   data.Chebyshev,
+}
+
+// Cell is a source module that generates 3D cell noise. Unlike the cell
+// noise in package rand, the output is remapped to [-1,1] to match the
+// other modules. Like rand.CellNoise3D, it is not safe for concurrent use.
+type Cell struct {
+	Seed          int64
+	Lambda        int
+	MaxPtsPerCell int
+	// Metric is one of "euclidean", "euclideansq", "manhattan" or "chebyshev".
+	Metric string
+	noise  *rand.CellNoise3D
+}
+
+// NewCell creates a Cell module. See rand.NewCellNoise3D. Panics if
+// metric isn't one of the known names.
+func NewCell(seed int64, lambda, maxPtsPerCell int, metric string) *Cell {
+	dist, ok := metrics[metric]
+	if !ok {
+		panic(fmt.Errorf("unknown metric %q", metric))
+	}
+	return &Cell{
+		Seed:          seed,
+		Lambda:        lambda,
+		MaxPtsPerCell: maxPtsPerCell,
+		Metric:        metric,
+		noise:         rand.NewCellNoise3D(seed, lambda, maxPtsPerCell, dist),
+	}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Cell) Noise(x, y, z float64) float64 {
+	return 2*m.noise.Noise(x, y, z) - 1
+}
+
+// Spec describes the module.
+func (m *Cell) Spec() *Spec {
+	s := spec("cell", map[string]float64{
+		"lambda": float64(m.Lambda),
+		"max":    float64(m.MaxPtsPerCell),
+	})
+	s.Seed = m.Seed
+	s.Func = m.Metric
+	return s
+}
+
+// Const is a source module that always produces the same value.
+type Const struct {
+	Value float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Const) Noise(x, y, z float64) float64 {
+	return m.Value
+}
+
+// Spec describes the module.
+func (m *Const) Spec() *Spec {
+	return spec("const", map[string]float64{"value": m.Value})
+}
@@ -0,0 +1,112 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/quillaja/goutil/rand"
+)
+
+// Module is a node in a noise graph.
+type Module interface {
+	rand.Noise3D
+	// Spec describes the module and all its sources.
+	Spec() *Spec
+}
+
+// Spec is a serializable description of a Module and its sources.
+type Spec struct {
+	// Type is the name of the module, eg "perlin" or "scalebias".
+	Type string `json:"type"`
+	// Seed is used by modules which generate noise.
+	Seed int64 `json:"seed,omitempty"`
+	// Func names a function, such as the curve used by "curve" or the
+	// distance metric used by "cell".
+	Func string `json:"func,omitempty"`
+	// Params holds any numeric parameters of the module.
+	Params map[string]float64 `json:"params,omitempty"`
+	// Points holds the control points used by "terrace".
+	Points []float64 `json:"points,omitempty"`
+	// Sources are the modules this one gets its input from.
+	Sources []*Spec `json:"sources,omitempty"`
+}
+
+// a builder makes a module from its spec and already built sources.
+type builder func(s *Spec, src []Module) (Module, error)
+
+// maps Spec.Type to the builder for that type of module.
+// filled in by the init() functions of each file.
+var builders = map[string]builder{}
+
+// registers a builder that requires exactly nsrc sources.
+func register(typ string, nsrc int, b builder) {
+	builders[typ] = func(s *Spec, src []Module) (Module, error) {
+		if len(src) != nsrc {
+			return nil, fmt.Errorf("%s: needs %d sources but has %d", typ, nsrc, len(src))
+		}
+		return b(s, src)
+	}
+}
+
+// Build creates the graph of modules described by the spec.
+func Build(s *Spec) (Module, error) {
+	if s == nil {
+		return nil, fmt.Errorf("nil spec")
+	}
+	b, ok := builders[s.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown module type %q", s.Type)
+	}
+
+	src := make([]Module, len(s.Sources))
+	for i, ss := range s.Sources {
+		m, err := Build(ss)
+		if err != nil {
+			return nil, err
+		}
+		src[i] = m
+	}
+	return b(s, src)
+}
+
+// Marshal encodes the graph starting at m as JSON.
+func Marshal(m Module) ([]byte, error) {
+	return json.MarshalIndent(m.Spec(), "", "  ")
+}
+
+// Unmarshal decodes a JSON spec and builds the graph it describes.
+func Unmarshal(data []byte) (Module, error) {
+	s := new(Spec)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return Build(s)
+}
+
+// param gets a parameter from the spec, or def if it's missing.
+func (s *Spec) param(name string, def float64) float64 {
+	if v, ok := s.Params[name]; ok {
+		return v
+	}
+	return def
+}
+
+// intParam gets an integer parameter from the spec, or def if it's missing.
+// It's an error if the value isn't a whole number in [min, max].
+func (s *Spec) intParam(name string, def, min, max int) (int, error) {
+	v := s.param(name, float64(def))
+	if v != math.Trunc(v) || v < float64(min) || float64(max) < v {
+		return 0, fmt.Errorf("%s: %s must be a whole number in [%d, %d] but is %g", s.Type, name, min, max, v)
+	}
+	return int(v), nil
+}
+
+// makes a spec for a module with the given params and sources.
+func spec(typ string, params map[string]float64, src ...Module) *Spec {
+	s := &Spec{Type: typ, Params: params}
+	for _, m := range src {
+		s.Sources = append(s.Sources, m.Spec())
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package module
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/rand"
+)
+
+func init() {
+	register("translate", 1, func(s *Spec, src []Module) (Module, error) {
+		return &Translate{Source: src[0], X: s.param("x", 0), Y: s.param("y", 0), Z: s.param("z", 0)}, nil
+	})
+	register("scale", 1, func(s *Spec, src []Module) (Module, error) {
+		return &Scale{Source: src[0], X: s.param("x", 1), Y: s.param("y", 1), Z: s.param("z", 1)}, nil
+	})
+	register("rotate", 1, func(s *Spec, src []Module) (Module, error) {
+		return NewRotate(src[0], s.param("x", 0), s.param("y", 0), s.param("z", 0)), nil
+	})
+	register("turbulence", 1, func(s *Spec, src []Module) (Module, error) {
+		roughness, err := s.intParam("roughness", 3, 1, 30)
+		if err != nil {
+			return nil, err
+		}
+		return NewTurbulence(src[0], s.Seed, s.param("frequency", 1), s.param("power", 1), roughness), nil
+	})
+}
+
+// Translate is a transformer module that moves the input coordinates
+// by (X,Y,Z) before passing them to the source.
+type Translate struct {
+	Source  Module
+	X, Y, Z float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Translate) Noise(x, y, z float64) float64 {
+	return m.Source.Noise(x+m.X, y+m.Y, z+m.Z)
+}
+
+// Spec describes the module.
+func (m *Translate) Spec() *Spec {
+	return spec("translate", map[string]float64{"x": m.X, "y": m.Y, "z": m.Z}, m.Source)
+}
+
+// Scale is a transformer module that multiplies the input coordinates
+// by (X,Y,Z) before passing them to the source. Larger values increase
+// the frequency of the noise.
+type Scale struct {
+	Source  Module
+	X, Y, Z float64
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Scale) Noise(x, y, z float64) float64 {
+	return m.Source.Noise(x*m.X, y*m.Y, z*m.Z)
+}
+
+// Spec describes the module.
+func (m *Scale) Spec() *Spec {
+	return spec("scale", map[string]float64{"x": m.X, "y": m.Y, "z": m.Z}, m.Source)
+}
+
+// Rotate is a transformer module that rotates the input coordinates around
+// the origin before passing them to the source. Angles are in degrees
+// around each axis, applied in x, y, z order.
+type Rotate struct {
+	Source  Module
+	X, Y, Z float64
+	m       [3][3]float64
+}
+
+// NewRotate creates a Rotate module.
+func NewRotate(source Module, x, y, z float64) *Rotate {
+	sx, cx := math.Sincos(x * math.Pi / 180)
+	sy, cy := math.Sincos(y * math.Pi / 180)
+	sz, cz := math.Sincos(z * math.Pi / 180)
+	// Rz * Ry * Rx
+	return &Rotate{
+		Source: source,
+		X:      x, Y: y, Z: z,
+		m: [3][3]float64{
+			{cz * cy, cz*sy*sx - sz*cx, cz*sy*cx + sz*sx},
+			{sz * cy, sz*sy*sx + cz*cx, sz*sy*cx - cz*sx},
+			{-sy, cy * sx, cy * cx},
+		},
+	}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Rotate) Noise(x, y, z float64) float64 {
+	r := &m.m
+	return m.Source.Noise(
+		r[0][0]*x+r[0][1]*y+r[0][2]*z,
+		r[1][0]*x+r[1][1]*y+r[1][2]*z,
+		r[2][0]*x+r[2][1]*y+r[2][2]*z)
+}
+
+// Spec describes the module.
+func (m *Rotate) Spec() *Spec {
+	return spec("rotate", map[string]float64{"x": m.X, "y": m.Y, "z": m.Z}, m.Source)
+}
+
+// Turbulence is a transformer module that randomly displaces the input
+// coordinates with fractal perlin noise before passing them to the source.
+type Turbulence struct {
+	Source Module
+	Seed   int64
+	// Frequency of the displacement noise.
+	Frequency float64
+	// Power is how far the coordinates can be moved.
+	Power float64
+	// Roughness is the number of octaves of the displacement noise.
+	Roughness int
+	warp      *rand.Warp
+}
+
+// NewTurbulence creates a Turbulence module.
+func NewTurbulence(source Module, seed int64, frequency, power float64, roughness int) *Turbulence {
+	var axes [3]*rand.Fractal
+	for i := range axes {
+		axes[i] = rand.NewFractal(rand.NewPerlin(seed+int64(i)), rand.FBM)
+		axes[i].Frequency = frequency
+		axes[i].Octaves = roughness
+	}
+	displace := rand.VectorField3DFunc(func(x, y, z float64) (vx, vy, vz float64) {
+		return axes[0].Noise(x, y, z), axes[1].Noise(x, y, z), axes[2].Noise(x, y, z)
+	})
+
+	return &Turbulence{
+		Source:    source,
+		Seed:      seed,
+		Frequency: frequency,
+		Power:     power,
+		Roughness: roughness,
+		warp: &rand.Warp{
+			Source:     source,
+			Displace:   displace,
+			Strength:   power,
+			Iterations: 1,
+		},
+	}
+}
+
+// Noise gets the value at (x,y,z).
+func (m *Turbulence) Noise(x, y, z float64) float64 {
+	return m.warp.Noise(x, y, z)
+}
+
+// Spec describes the module.
+func (m *Turbulence) Spec() *Spec {
+	s := spec("turbulence", map[string]float64{
+		"frequency": m.Frequency,
+		"power":     m.Power,
+		"roughness": float64(m.Roughness),
+	}, m.Source)
+	s.Seed = m.Seed
+	return s
+}
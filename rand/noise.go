@@ -0,0 +1,39 @@
+package rand
+
+// Noise3D is implemented by anything that produces a noise value at a
+// point in 3D space, such as CellNoise3D.
+type Noise3D interface {
+	Noise(x, y, z float64) float64
+}
+
+// Noise2D is implemented by anything that produces a noise value at a
+// point in 2D space, such as CellNoise2D.
+type Noise2D interface {
+	Noise(x, y float64) float64
+}
+
+// Noise3DFunc allows an ordinary function such as Noise3 to be used
+// as a Noise3D.
+type Noise3DFunc func(x, y, z float64) float64
+
+// Noise calls f(x, y, z).
+func (f Noise3DFunc) Noise(x, y, z float64) float64 {
+	return f(x, y, z)
+}
+
+// Noise2DFunc allows an ordinary function such as Noise2 to be used
+// as a Noise2D.
+type Noise2DFunc func(x, y float64) float64
+
+// Noise calls f(x, y).
+func (f Noise2DFunc) Noise(x, y float64) float64 {
+	return f(x, y)
+}
+
+// Lift2D makes a Noise2D usable where a Noise3D is expected by
+// ignoring the z coordinate.
+func Lift2D(n Noise2D) Noise3D {
+	return Noise3DFunc(func(x, y, z float64) float64 {
+		return n.Noise(x, y)
+	})
+}
@@ -0,0 +1,79 @@
+package noisemap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WritePGM writes the map as a binary 16-bit PGM (portable graymap) image,
+// where low and below is 0 and high and above is 65535.
+//
+// See: http://netpbm.sourceforge.net/doc/pgm.html
+func (m *Map) WritePGM(w io.Writer, low, high float64) error {
+	bw := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(bw, "P5\n%d %d\n65535\n", m.Width, m.Height); err != nil {
+		return err
+	}
+	buf := make([]byte, 2)
+	for _, v := range m.Values {
+		binary.BigEndian.PutUint16(buf, uint16(unit(v, low, high)*0xFFFF+0.5))
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WritePFM writes the map as a grayscale PFM (portable float map) image,
+// which stores the raw values as float32s. As the format requires, rows
+// are written bottom to top, and in little-endian order.
+//
+// See: http://www.pauldebevec.com/Research/HDR/PFM/
+func (m *Map) WritePFM(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	// negative scale means little-endian
+	if _, err := fmt.Fprintf(bw, "Pf\n%d %d\n-1.0\n", m.Width, m.Height); err != nil {
+		return err
+	}
+	buf := make([]byte, 4)
+	for y := m.Height - 1; y >= 0; y-- {
+		for x := 0; x < m.Width; x++ {
+			binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(m.At(x, y))))
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteRaw16 writes the map as headerless little-endian 16-bit values, as
+// used by many terrain tools for heightmaps, where low and below is 0 and
+// high and above is 65535.
+func (m *Map) WriteRaw16(w io.Writer, low, high float64) error {
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 2)
+	for _, v := range m.Values {
+		binary.LittleEndian.PutUint16(buf, uint16(unit(v, low, high)*0xFFFF+0.5))
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteRaw32 writes the map as headerless little-endian float32 values.
+func (m *Map) WriteRaw32(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 4)
+	for _, v := range m.Values {
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(v)))
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,104 @@
+package noisemap
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/quillaja/goutil/num"
+)
+
+// normalizes v from [low,high] to [0,1], clamped. NaN gives 0.
+func unit(v, low, high float64) float64 {
+	if high == low || math.IsNaN(v) {
+		return 0
+	}
+	return num.ClampFloat((v-low)/(high-low), 0, 1)
+}
+
+// Gray16 converts the map to a grayscale image, where low and below is black
+// and high and above is white. NaN is black. Use Range() to get the map's
+// actual range.
+func (m *Map) Gray16(low, high float64) *image.Gray16 {
+	img := image.NewGray16(image.Rect(0, 0, m.Width, m.Height))
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			v := unit(m.At(x, y), low, high)
+			img.SetGray16(x, y, color.Gray16{Y: uint16(v*0xFFFF + 0.5)})
+		}
+	}
+	return img
+}
+
+// Stop is a color at a position in a Gradient.
+type Stop struct {
+	Pos   float64
+	Color color.RGBA
+}
+
+// Gradient maps values to colors by linearly interpolating between stops.
+type Gradient []Stop
+
+// NewGradient creates a gradient from the stops, sorted by position.
+// Panics if no stops are given.
+func NewGradient(stops ...Stop) Gradient {
+	if len(stops) == 0 {
+		panic(fmt.Errorf("Invalid params: gradient needs at least 1 stop"))
+	}
+	g := append(Gradient(nil), stops...)
+	sort.Slice(g, func(i, j int) bool { return g[i].Pos < g[j].Pos })
+	return g
+}
+
+// Grayscale is a gradient from black at -1 to white at 1.
+var Grayscale = NewGradient(
+	Stop{-1, color.RGBA{0, 0, 0, 255}},
+	Stop{1, color.RGBA{255, 255, 255, 255}})
+
+// Terrain is a gradient from deep water at -1 to snow at 1.
+var Terrain = NewGradient(
+	Stop{-1, color.RGBA{0, 0, 128, 255}},
+	Stop{-0.25, color.RGBA{0, 0, 255, 255}},
+	Stop{0, color.RGBA{0, 128, 255, 255}},
+	Stop{0.0625, color.RGBA{240, 240, 64, 255}},
+	Stop{0.125, color.RGBA{32, 160, 0, 255}},
+	Stop{0.375, color.RGBA{224, 224, 0, 255}},
+	Stop{0.75, color.RGBA{128, 128, 128, 255}},
+	Stop{1, color.RGBA{255, 255, 255, 255}})
+
+// Color gets the color for the value v. NaN gets the color of the first stop.
+func (g Gradient) Color(v float64) color.RGBA {
+	if v <= g[0].Pos || math.IsNaN(v) {
+		return g[0].Color
+	}
+	last := g[len(g)-1]
+	if v >= last.Pos {
+		return last.Color
+	}
+
+	i := sort.Search(len(g), func(i int) bool { return g[i].Pos > v })
+	a, b := g[i-1], g[i]
+	t := unit(v, a.Pos, b.Pos)
+	lerp := func(x, y uint8) uint8 {
+		return uint8(num.UnitLerp(t, float64(x), float64(y)) + 0.5)
+	}
+	return color.RGBA{
+		R: lerp(a.Color.R, b.Color.R),
+		G: lerp(a.Color.G, b.Color.G),
+		B: lerp(a.Color.B, b.Color.B),
+		A: lerp(a.Color.A, b.Color.A),
+	}
+}
+
+// RGBA converts the map to a color image using the gradient.
+func (m *Map) RGBA(g Gradient) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, m.Width, m.Height))
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			img.SetRGBA(x, y, g.Color(m.At(x, y)))
+		}
+	}
+	return img
+}
@@ -0,0 +1,106 @@
+// Package noisemap samples noise into grids of values, which can then be
+// turned into images or saved as heightmaps. Nothing here needs a window,
+// so it's suitable for headless use and golden-file tests.
+package noisemap
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/quillaja/goutil/rand"
+)
+
+// Rect is a rectangular region of noise space.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Map is a grid of sampled noise values, stored in row-major order.
+// Row 0 corresponds to MinY of the region sampled.
+type Map struct {
+	Width, Height int
+	Values        []float64
+}
+
+// New creates an empty Map.
+func New(width, height int) *Map {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Errorf("Invalid params: %dx%d is not a valid size", width, height))
+	}
+	return &Map{
+		Width:  width,
+		Height: height,
+		Values: make([]float64, width*height),
+	}
+}
+
+// At gets the value at column x, row y.
+func (m *Map) At(x, y int) float64 {
+	return m.Values[y*m.Width+x]
+}
+
+// Set sets the value at column x, row y.
+func (m *Map) Set(x, y int, v float64) {
+	m.Values[y*m.Width+x] = v
+}
+
+// Range gets the smallest and largest values in the map.
+func (m *Map) Range() (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, v := range m.Values {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return
+}
+
+// Sample creates a width x height Map by sampling noise over the region r.
+// Each value is taken at the center of its cell of the region. Rows are
+// sampled in parallel, so noise must be safe for concurrent use (eg
+// rand.Noise2DFunc(rand.Noise2) or *rand.Worley2D, but not *rand.CellNoise2D).
+func Sample(noise rand.Noise2D, r Rect, width, height int) *Map {
+	m := New(width, height)
+	dx := (r.MaxX - r.MinX) / float64(width)
+	dy := (r.MaxY - r.MinY) / float64(height)
+
+	rows := make(chan int, height)
+	for y := 0; y < height; y++ {
+		rows <- y
+	}
+	close(rows)
+
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range rows {
+				ny := r.MinY + (float64(y)+0.5)*dy
+				row := m.Values[y*width : (y+1)*width]
+				for x := range row {
+					row[x] = noise.Noise(r.MinX+(float64(x)+0.5)*dx, ny)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return m
+}
+
+// SampleSerial is the same as Sample but uses only the calling goroutine,
+// so noise doesn't need to be safe for concurrent use.
+func SampleSerial(noise rand.Noise2D, r Rect, width, height int) *Map {
+	m := New(width, height)
+	dx := (r.MaxX - r.MinX) / float64(width)
+	dy := (r.MaxY - r.MinY) / float64(height)
+	for y := 0; y < height; y++ {
+		ny := r.MinY + (float64(y)+0.5)*dy
+		for x := 0; x < width; x++ {
+			m.Values[y*width+x] = noise.Noise(r.MinX+(float64(x)+0.5)*dx, ny)
+		}
+	}
+	return m
+}
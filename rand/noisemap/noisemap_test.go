@@ -0,0 +1,137 @@
+package noisemap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/quillaja/goutil/rand"
+)
+
+var region = Rect{MinX: 0, MinY: 0, MaxX: 8, MaxY: 4}
+
+func TestSample(t *testing.T) {
+	perlin := rand.NewPerlin(1)
+	noise := rand.Noise2DFunc(func(x, y float64) float64 { return perlin.Noise(x, y, 0.5) })
+
+	par := Sample(noise, region, 64, 32)
+	ser := SampleSerial(noise, region, 64, 32)
+	for i := range par.Values {
+		if par.Values[i] != ser.Values[i] {
+			t.Fatalf("parallel and serial differ at %d: %g != %g", i, par.Values[i], ser.Values[i])
+		}
+	}
+
+	// value at the center of pixel (10,5)
+	if got, want := par.At(10, 5), perlin.Noise(10.5/8, 5.5/8, 0.5); got != want {
+		t.Errorf("At(10,5) = %g, want %g", got, want)
+	}
+}
+
+func TestWritePGM_Golden(t *testing.T) {
+	perlin := rand.NewPerlin(1)
+	noise := rand.Noise2DFunc(func(x, y float64) float64 { return perlin.Noise(x, y, 0.5) })
+	m := Sample(noise, region, 64, 32)
+
+	var buf bytes.Buffer
+	if err := m.WritePGM(&buf, -1, 1); err != nil {
+		t.Fatal(err)
+	}
+	header := "P5\n64 32\n65535\n"
+	if !bytes.HasPrefix(buf.Bytes(), []byte(header)) {
+		t.Fatalf("bad header: %q", buf.Bytes()[:len(header)])
+	}
+	if buf.Len() != len(header)+64*32*2 {
+		t.Errorf("length = %d", buf.Len())
+	}
+
+	const golden = "767160c6aee49d606f013f300d1be632ce348d482aa16518a24a4866ecebac04"
+	if got := fmt.Sprintf("%x", sha256.Sum256(buf.Bytes())); got != golden {
+		t.Errorf("sha256 = %s, want %s", got, golden)
+	}
+}
+
+func TestWritePFM(t *testing.T) {
+	m := New(3, 2)
+	copy(m.Values, []float64{0, 1, 2, 3, 4, 5})
+
+	var buf bytes.Buffer
+	if err := m.WritePFM(&buf); err != nil {
+		t.Fatal(err)
+	}
+	header := "Pf\n3 2\n-1.0\n"
+	data := buf.Bytes()[len(header):]
+	// bottom row first
+	want := []float32{3, 4, 5, 0, 1, 2}
+	for i, w := range want {
+		got := math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+		if got != w {
+			t.Errorf("value %d = %g, want %g", i, got, w)
+		}
+	}
+}
+
+func TestGray16(t *testing.T) {
+	m := New(4, 1)
+	copy(m.Values, []float64{-2, 0, 1, math.NaN()})
+	img := m.Gray16(-1, 1)
+	want := []uint16{0, 0x8000, 0xFFFF, 0}
+	for x, w := range want {
+		if got := img.Gray16At(x, 0).Y; got != w {
+			t.Errorf("pixel %d = %#x, want %#x", x, got, w)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	g := NewGradient(
+		Stop{1, color.RGBA{255, 255, 255, 255}},
+		Stop{-1, color.RGBA{0, 0, 0, 255}},
+		Stop{0, color.RGBA{255, 0, 0, 255}})
+	tests := []struct {
+		v    float64
+		want color.RGBA
+	}{
+		{v: -5, want: color.RGBA{0, 0, 0, 255}},
+		{v: -0.5, want: color.RGBA{128, 0, 0, 255}},
+		{v: 0, want: color.RGBA{255, 0, 0, 255}},
+		{v: 0.5, want: color.RGBA{255, 128, 128, 255}},
+		{v: 5, want: color.RGBA{255, 255, 255, 255}},
+		{v: math.NaN(), want: color.RGBA{0, 0, 0, 255}},
+	}
+	for _, tt := range tests {
+		if got := g.Color(tt.v); got != tt.want {
+			t.Errorf("Color(%g) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+
+	m := New(3, 1)
+	copy(m.Values, []float64{-1, 0, math.NaN()})
+	img := m.RGBA(g)
+	if got := img.RGBAAt(1, 0); got != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("RGBA pixel 1 = %v", got)
+	}
+	if got := img.RGBAAt(2, 0); got != (color.RGBA{0, 0, 0, 255}) {
+		t.Errorf("RGBA NaN pixel = %v", got)
+	}
+}
+
+func BenchmarkSample(b *testing.B) {
+	perlin := rand.NewPerlin(1)
+	noise := rand.Noise2DFunc(func(x, y float64) float64 { return perlin.Noise(x, y, 0.5) })
+	for i := 0; i < b.N; i++ {
+		Sample(noise, region, 256, 256)
+	}
+}
+
+func BenchmarkSampleSerial(b *testing.B) {
+	perlin := rand.NewPerlin(1)
+	noise := rand.Noise2DFunc(func(x, y float64) float64 { return perlin.Noise(x, y, 0.5) })
+	for i := 0; i < b.N; i++ {
+		SampleSerial(noise, region, 256, 256)
+	}
+}
@@ -33,8 +33,9 @@ func run() {
 	canvas := pixelgl.NewCanvas(pixel.R(0, 0, win.Bounds().W()/scale, win.Bounds().H()/scale))
 
 	// perlin noise generation params
-	xoff, yoff, zoff := 0.0, 0.0, 0.0
-	xdelta, ydelta, zdelta := 0.02, 0.02, 0.01
+	zoff := 0.0
+	delta, zdelta := 0.02, 0.01 // grid spacing in xy, and change in z per frame
+	var noise []float64
 
 	// example of using FillPermutation
 	// rand.FillPermutation(1)
@@ -49,24 +50,21 @@ func run() {
 		winh, winw := int(canvas.Bounds().H()), int(canvas.Bounds().W())
 		start := time.Now()
 		pixels := canvas.Pixels()
+		if len(noise) != winw*winh {
+			noise = make([]float64, winw*winh)
+		}
+		rand.FillGrid3D(noise, winw, winh, 1, [3]float64{0, 0, zoff}, delta)
 		for y := 0; y < winh; y++ {
 			for x := 0; x < winw; x++ {
-				// h := num.Lerp(rand.Noise3Octaves(xoff, yoff, zoff, 2, 2, 0.5), -1, 1, 0, 360)
-				// h := uint8(num.Lerp(math.Pow(1+rand.Noise3(xoff, yoff, zoff), 2), 0, 4, 0, 255))
-				h := num.Lerp(rand.Noise3(xoff, yoff, zoff), -1, 1, 0, 360)
+				h := num.Remap(noise[y*winw+x], -1, 1, 0, 360)
 				r, g, b := colorful.Hsv(h, 1, 1).RGB255()
 				i := pxu.PixIndex(x, y, winw)
 				pixels[i+0] = r   // r
 				pixels[i+1] = g   // g
 				pixels[i+2] = b   // b
 				pixels[i+3] = 255 // a
-
-				xoff += xdelta
 			}
-			yoff += ydelta
-			xoff = 0.0
 		}
-		yoff = 0.0
 		zoff += zdelta
 		canvas.SetPixels(pixels)
 		avg.Add(time.Since(start).Seconds() * 1000) //profiling
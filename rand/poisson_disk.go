@@ -0,0 +1,271 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/quillaja/goutil/data"
+)
+
+// number of candidates tried around each active point in Bridson's algorithm.
+const bridsonK = 30
+
+// PoissonDisk2D generates evenly spaced random points (blue noise) inside
+// bounds, which holds the {min,max} range of each axis like data.KDTree's
+// QueryRange(). No 2 points are closer than minDist, and no spot in bounds
+// is farther than 2*minDist from a point. Uses Bridson's algorithm.
+//
+// See: https://www.cs.ubc.ca/~rbridson/docs/bridson-siggraph07-poissondisk.pdf
+func PoissonDisk2D(bounds [2][2]float64, minDist float64, seed int64) [][2]float64 {
+	pts := bridson(bounds[:], minDist, nil, minDist, New(NewXoshiro256(uint64(seed))))
+	out := make([][2]float64, len(pts))
+	for i, p := range pts {
+		copy(out[i][:], p)
+	}
+	return out
+}
+
+// PoissonDisk3D is the same as PoissonDisk2D, but in 3D.
+func PoissonDisk3D(bounds [3][2]float64, minDist float64, seed int64) [][3]float64 {
+	pts := bridson(bounds[:], minDist, nil, minDist, New(NewXoshiro256(uint64(seed))))
+	out := make([][3]float64, len(pts))
+	for i, p := range pts {
+		copy(out[i][:], p)
+	}
+	return out
+}
+
+// PoissonDiskVariable2D is like PoissonDisk2D, but the spacing of points
+// changes across bounds according to density, which should give values
+// in [0,1] (eg a remapped Noise2). Where density is 1 points are minDist
+// apart, and where it is 0 they're maxDist apart. Densities outside of
+// [0,1] are clamped, and NaN is treated as 0.
+func PoissonDiskVariable2D(bounds [2][2]float64, minDist, maxDist float64, density func(x, y float64) float64, seed int64) [][2]float64 {
+	if !(minDist <= maxDist) || math.IsInf(maxDist, 1) {
+		panic(fmt.Errorf("Invalid params: maxDist %g not finite and >= minDist %g", maxDist, minDist))
+	}
+	radius := func(p []float64) float64 {
+		d := density(p[0], p[1])
+		if math.IsNaN(d) {
+			d = 0
+		}
+		d = math.Max(0, math.Min(1, d))
+		return maxDist + d*(minDist-maxDist)
+	}
+	pts := bridson(bounds[:], minDist, radius, maxDist, New(NewXoshiro256(uint64(seed))))
+	out := make([][2]float64, len(pts))
+	for i, p := range pts {
+		copy(out[i][:], p)
+	}
+	return out
+}
+
+// does Bridson's algorithm in any number of dimensions. if radius is nil,
+// every point uses minDist. otherwise radius gives the spacing around each
+// point, which must be in [minDist,maxDist].
+func bridson(bounds [][2]float64, minDist float64, radius func([]float64) float64, maxDist float64, r *Rand) [][]float64 {
+	if !(minDist > 0) {
+		panic(fmt.Errorf("Invalid params: minDist %g not > 0", minDist))
+	}
+	dims := len(bounds)
+	if radius == nil {
+		radius = func([]float64) float64 { return minDist }
+	}
+
+	// background grid, sized so each cell holds at most 1 point.
+	// each entry is an index into pts, or -1.
+	cellSize := minDist / math.Sqrt(float64(dims))
+	size := make([]int, dims)
+	ncells := 1
+	for i, b := range bounds {
+		if b[1] <= b[0] {
+			panic(fmt.Errorf("Invalid params: bounds %v", b))
+		}
+		size[i] = int(math.Ceil((b[1] - b[0]) / cellSize))
+		ncells *= size[i]
+	}
+	grid := make([]int, ncells)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p []float64) (idx int, cell []int) {
+		cell = make([]int, dims)
+		for i := dims - 1; i >= 0; i-- {
+			cell[i] = int((p[i] - bounds[i][0]) / cellSize)
+			if cell[i] >= size[i] {
+				cell[i] = size[i] - 1
+			}
+			idx = idx*size[i] + cell[i]
+		}
+		return
+	}
+
+	var pts [][]float64
+	var radii []float64
+	add := func(p []float64, rad float64) {
+		idx, _ := cellOf(p)
+		grid[idx] = len(pts)
+		pts = append(pts, p)
+		radii = append(radii, rad)
+	}
+
+	// checks that no point is too close to p
+	reach := int(math.Ceil(maxDist / cellSize))
+	fits := func(p []float64, rad float64) bool {
+		for i, b := range bounds {
+			if p[i] < b[0] || p[i] >= b[1] {
+				return false
+			}
+		}
+		_, cell := cellOf(p)
+		lo, hi := make([]int, dims), make([]int, dims)
+		for i := range cell {
+			lo[i], hi[i] = max(cell[i]-reach, 0), min(cell[i]+reach, size[i]-1)
+		}
+		// visit every cell in [lo,hi] like an odometer
+		cur := append([]int(nil), lo...)
+		for {
+			idx := 0
+			for i := dims - 1; i >= 0; i-- {
+				idx = idx*size[i] + cur[i]
+			}
+			if j := grid[idx]; j >= 0 {
+				d := math.Max(rad, radii[j])
+				if distSq(p, pts[j]) < d*d {
+					return false
+				}
+			}
+
+			i := 0
+			for ; i < dims; i++ {
+				if cur[i] < hi[i] {
+					cur[i]++
+					break
+				}
+				cur[i] = lo[i]
+			}
+			if i == dims {
+				return true
+			}
+		}
+	}
+
+	// start with a random point
+	first := make([]float64, dims)
+	for i, b := range bounds {
+		first[i] = r.Float64NM(b[0], b[1])
+	}
+	add(first, radius(first))
+	active := []int{0}
+
+	for len(active) > 0 {
+		ai := r.Intn(len(active))
+		p, rp := pts[active[ai]], radii[active[ai]]
+
+		found := false
+		for k := 0; k < bridsonK; k++ {
+			// random point in the spherical shell [r,2r] around p,
+			// uniform by volume
+			q := r.unitVector(dims)
+			inner, outer := math.Pow(rp, float64(dims)), math.Pow(2*rp, float64(dims))
+			dist := math.Pow(inner+r.Float64()*(outer-inner), 1/float64(dims))
+			for i := range q {
+				q[i] = p[i] + q[i]*dist
+			}
+
+			if rq := radius(q); fits(q, rq) {
+				add(q, rq)
+				active = append(active, len(pts)-1)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[ai] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+
+	return pts
+}
+
+// squared euclidean distance.
+func distSq(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// random unit vector in dims dimensions.
+func (r *Rand) unitVector(dims int) []float64 {
+	v := make([]float64, dims)
+	for {
+		l := 0.0
+		for i := range v {
+			v[i] = r.Normal(0, 1)
+			l += v[i] * v[i]
+		}
+		if l > 1e-12 {
+			l = math.Sqrt(l)
+			for i := range v {
+				v[i] /= l
+			}
+			return v
+		}
+	}
+}
+
+// BestCandidate2D generates n well spaced random points inside bounds using
+// Mitchell's best-candidate algorithm: for each point, several random
+// candidates are tried and the one farthest from any existing point is kept.
+// More candidates give more even spacing. Unlike PoissonDisk2D, the number
+// of points is chosen by the caller, and points can be added progressively
+// (the first k points are always well spaced).
+func BestCandidate2D(bounds [2][2]float64, n, candidates int, seed int64) [][2]float64 {
+	if candidates < 1 {
+		panic(fmt.Errorf("Invalid params: %d candidates", candidates))
+	}
+	r := New(NewXoshiro256(uint64(seed)))
+	rnd := func() *point {
+		return &point{
+			r.Float64NM(bounds[0][0], bounds[0][1]),
+			r.Float64NM(bounds[1][0], bounds[1][1])}
+	}
+
+	// the tree is rebuilt as it doubles in size. points added since the last
+	// rebuild are checked by brute force.
+	tree := data.NewKDTree(2)
+	var all []data.Interface
+	pending := 0
+
+	out := make([][2]float64, 0, n)
+	for i := 0; i < n; i++ {
+		var best *point
+		bestDist := -1.0
+		for c := 0; c < candidates; c++ {
+			cand := rnd()
+			d := math.Inf(1)
+			if nn := tree.NearestNeighbor(data.EuclideanSq, cand[:]...); nn != nil {
+				d = data.EuclideanSq(cand[:], nn.Location())
+			}
+			for _, p := range all[len(all)-pending:] {
+				d = math.Min(d, data.EuclideanSq(cand[:], p.Location()))
+			}
+			if d > bestDist {
+				best, bestDist = cand, d
+			}
+		}
+
+		all = append(all, best)
+		out = append(out, *best)
+		pending++
+		if pending > tree.Len() {
+			tree.Build(append([]data.Interface(nil), all...))
+			pending = 0
+		}
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+var diskBounds = [2][2]float64{{0, 20}, {-5, 5}}
+
+// smallest distance between any 2 points, by brute force.
+func minSpacing(pts [][]float64) float64 {
+	min := math.Inf(1)
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			min = math.Min(min, math.Sqrt(distSq(pts[i], pts[j])))
+		}
+	}
+	return min
+}
+
+func TestPoissonDisk2D(t *testing.T) {
+	const r = 0.5
+	pts := PoissonDisk2D(diskBounds, r, 1)
+	flat := make([][]float64, len(pts))
+	for i := range pts {
+		flat[i] = pts[i][:]
+		if !(0 <= pts[i][0] && pts[i][0] < 20 && -5 <= pts[i][1] && pts[i][1] < 5) {
+			t.Errorf("point %v out of bounds", pts[i])
+		}
+	}
+	if d := minSpacing(flat); d < r {
+		t.Errorf("points %g apart, closer than %g", d, r)
+	}
+
+	// the result should be maximal: every spot is within 2r of a point
+	rnd := New(NewSplitMix64(2))
+	for i := 0; i < 1000; i++ {
+		probe := []float64{rnd.Float64NM(0, 20), rnd.Float64NM(-5, 5)}
+		near := math.Inf(1)
+		for _, p := range flat {
+			near = math.Min(near, math.Sqrt(distSq(probe, p)))
+		}
+		if near > 2*r {
+			t.Fatalf("%v is %g from the nearest point", probe, near)
+		}
+	}
+
+	// and deterministic
+	again := PoissonDisk2D(diskBounds, r, 1)
+	if len(again) != len(pts) || again[len(pts)-1] != pts[len(pts)-1] {
+		t.Error("not deterministic")
+	}
+}
+
+func TestPoissonDisk3D(t *testing.T) {
+	const r = 1.0
+	pts := PoissonDisk3D([3][2]float64{{0, 5}, {0, 5}, {0, 5}}, r, 1)
+	flat := make([][]float64, len(pts))
+	for i := range pts {
+		flat[i] = pts[i][:]
+	}
+	if len(pts) < 20 {
+		t.Errorf("only %d points", len(pts))
+	}
+	if d := minSpacing(flat); d < r {
+		t.Errorf("points %g apart, closer than %g", d, r)
+	}
+}
+
+func TestPoissonDiskVariable2D(t *testing.T) {
+	// dense on the left, sparse on the right
+	density := func(x, y float64) float64 { return 1 - x/20 }
+	pts := PoissonDiskVariable2D(diskBounds, 0.25, 1, density, 1)
+
+	left, right := 0, 0
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			d := math.Sqrt(distSq(pts[i][:], pts[j][:]))
+			ri := 1 + density(pts[i][0], pts[i][1])*(0.25-1)
+			rj := 1 + density(pts[j][0], pts[j][1])*(0.25-1)
+			if d < math.Max(ri, rj) {
+				t.Fatalf("%v and %v are %g apart", pts[i], pts[j], d)
+			}
+		}
+		if pts[i][0] < 5 {
+			left++
+		} else if pts[i][0] >= 15 {
+			right++
+		}
+	}
+	if left <= 2*right {
+		t.Errorf("left quarter has %d points, right has %d", left, right)
+	}
+}
+
+func TestPoissonDiskVariable2D_NaN(t *testing.T) {
+	// NaN density, eg from remapped noise, is the same as 0
+	density := func(x, y float64) float64 {
+		if x < 10 {
+			return math.NaN()
+		}
+		return 0
+	}
+	pts := PoissonDiskVariable2D(diskBounds, 0.25, 1, density, 1)
+	want := PoissonDiskVariable2D(diskBounds, 0.25, 1, func(x, y float64) float64 { return 0 }, 1)
+	if len(pts) != len(want) {
+		t.Fatalf("got %d points, want %d", len(pts), len(want))
+	}
+	for i := range pts {
+		if pts[i] != want[i] {
+			t.Fatalf("point %d is %v, want %v", i, pts[i], want[i])
+		}
+	}
+}
+
+func TestPoissonDisk_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"zero minDist", func() { PoissonDisk2D(diskBounds, 0, 1) }},
+		{"NaN minDist", func() { PoissonDisk2D(diskBounds, math.NaN(), 1) }},
+		{"maxDist < minDist", func() { PoissonDiskVariable2D(diskBounds, 1, 0.5, nil, 1) }},
+		{"NaN maxDist", func() { PoissonDiskVariable2D(diskBounds, 1, math.NaN(), nil, 1) }},
+		{"Inf maxDist", func() { PoissonDiskVariable2D(diskBounds, 1, math.Inf(1), nil, 1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestBestCandidate2D(t *testing.T) {
+	const n = 200
+	pts := BestCandidate2D(diskBounds, n, 10, 1)
+	if len(pts) != n {
+		t.Fatalf("got %d points", len(pts))
+	}
+	flat := make([][]float64, n)
+	for i := range pts {
+		flat[i] = pts[i][:]
+	}
+
+	// compare to the same number of uniformly random points
+	rnd := New(NewSplitMix64(1))
+	uniform := make([][]float64, n)
+	for i := range uniform {
+		uniform[i] = []float64{rnd.Float64NM(0, 20), rnd.Float64NM(-5, 5)}
+	}
+	best, unif := minSpacing(flat), minSpacing(uniform)
+	if best < 3*unif {
+		t.Errorf("best candidate spacing %g not much better than uniform %g", best, unif)
+	}
+}
+
+func BenchmarkPoissonDisk2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PoissonDisk2D(diskBounds, 0.25, int64(i))
+	}
+}
+
+func BenchmarkBestCandidate2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		BestCandidate2D(diskBounds, 500, 10, int64(i))
+	}
+}
@@ -0,0 +1,135 @@
+package rand
+
+import (
+	"fmt"
+	"math/bits"
+	"time"
+)
+
+// Rand generates random numbers from a Source. A Rand is not safe for
+// concurrent use unless its Source is.
+type Rand struct {
+	src Source
+}
+
+// New creates a Rand using the source.
+func New(src Source) *Rand {
+	return &Rand{src: src}
+}
+
+// Default is the Rand used by the package level functions such as Float64NM.
+// It is safe for concurrent use, and is seeded with time.Now().UnixNano()
+// unless Seed is called.
+var Default = New(defaultSource)
+
+// the source used by Default.
+var defaultSource = &lockedSource{src: NewPCG(uint64(time.Now().UnixNano()), 0)}
+
+// Seed makes Default deterministic by giving it a new source with the seed.
+func Seed(seed int64) {
+	defaultSource.mu.Lock()
+	defaultSource.src = NewPCG(uint64(seed), 0)
+	defaultSource.mu.Unlock()
+}
+
+// Uint64 gets 64 random bits.
+func (r *Rand) Uint64() uint64 {
+	return r.src.Uint64()
+}
+
+// Float64 gets a random float64 in the range [0,1).
+func (r *Rand) Float64() float64 {
+	return float64(r.src.Uint64()>>11) / (1 << 53)
+}
+
+// Float64NM produces a random float64 in the range [low,high).
+// Panics if low >= high.
+func (r *Rand) Float64NM(low, high float64) float64 {
+	if low >= high {
+		panic(fmt.Errorf("Invalid params: %g not <= %g", low, high))
+	}
+	return r.Float64()*(high-low) + low
+}
+
+// Intn gets a random int in the range [0,n), without bias.
+// Panics if n <= 0.
+//
+// See: https://arxiv.org/abs/1805.10941 (Lemire)
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic(fmt.Errorf("Invalid params: %d not > 0", n))
+	}
+	un := uint64(n)
+	hi, lo := bits.Mul64(r.src.Uint64(), un)
+	if lo < un {
+		threshold := -un % un
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.src.Uint64(), un)
+		}
+	}
+	return int(hi)
+}
+
+// IntNM produces a random int in the range [low,high).
+// Panics if low >= high.
+func (r *Rand) IntNM(low, high int) int {
+	if low >= high {
+		panic(fmt.Errorf("Invalid params: %d not <= %d", low, high))
+	}
+	return r.Intn(high-low) + low
+}
+
+// Perm gets a random permutation of the ints [0,n).
+func (r *Rand) Perm(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	r.Shuffle(n, func(i, j int) { p[i], p[j] = p[j], p[i] })
+	return p
+}
+
+// Shuffle randomizes the order of n elements using the Fisher-Yates shuffle.
+// swap swaps the elements with indexes i and j.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.Intn(i+1))
+	}
+}
+
+// Choice picks a random index into a collection of n elements, for example
+// items[r.Choice(len(items))]. Panics if n <= 0.
+func (r *Rand) Choice(n int) int {
+	return r.Intn(n)
+}
+
+// WeightedChoice picks a random index into weights, where the chance of
+// picking each index is proportional to its weight. Panics if there are
+// no weights, if any are negative, or if they're all 0. For picking many
+// times from the same weights, see Alias.
+func (r *Rand) WeightedChoice(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic(fmt.Errorf("Invalid params: negative weight %g", w))
+		}
+		total += w
+	}
+	if total <= 0 {
+		panic(fmt.Errorf("Invalid params: weights sum to %g", total))
+	}
+
+	x := r.Float64() * total
+	for i, w := range weights {
+		if x < w {
+			return i
+		}
+		x -= w
+	}
+	// only reachable through rounding error. pick the last non-zero weight.
+	for i := len(weights) - 1; ; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package rand
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestRand_Deterministic(t *testing.T) {
+	sources := map[string]func() Source{
+		"splitmix": func() Source { return NewSplitMix64(1) },
+		"pcg":      func() Source { return NewPCG(1, 2) },
+		"xoshiro":  func() Source { return NewXoshiro256(1) },
+	}
+	for name, src := range sources {
+		t.Run(name, func(t *testing.T) {
+			a, b := New(src()), New(src())
+			for i := 0; i < 1000; i++ {
+				if x, y := a.Float64(), b.Float64(); x != y {
+					t.Fatalf("%d: %g != %g", i, x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestRand_Intn(t *testing.T) {
+	// chi-square test of uniformity over 10 buckets (9 dof, p = 0.001)
+	r := New(NewXoshiro256(1))
+	counts := make([]int, 10)
+	for i := 0; i < 100000; i++ {
+		n := r.Intn(len(counts))
+		if n < 0 || n >= len(counts) {
+			t.Fatalf("%d out of range", n)
+		}
+		counts[n]++
+	}
+	if chi := chiSquareUniform(counts); chi > 27.88 {
+		t.Errorf("chi-square = %g, counts %v", chi, counts)
+	}
+}
+
+func TestRand_Perm(t *testing.T) {
+	r := New(NewPCG(1, 1))
+	for _, n := range []int{0, 1, 2, 10, 100} {
+		p := r.Perm(n)
+		sort.Ints(p)
+		for i := range p {
+			if p[i] != i {
+				t.Fatalf("Perm(%d) is not a permutation", n)
+			}
+		}
+	}
+}
+
+func TestRand_Shuffle(t *testing.T) {
+	// every element should end up in every position about equally often
+	r := New(NewSplitMix64(1))
+	const n = 5
+	counts := make([]int, n*n)
+	for i := 0; i < 20000; i++ {
+		s := []int{0, 1, 2, 3, 4}
+		r.Shuffle(n, func(i, j int) { s[i], s[j] = s[j], s[i] })
+		for pos, v := range s {
+			counts[v*n+pos]++
+		}
+	}
+	// 24 dof, p = 0.001
+	if chi := chiSquareUniform(counts); chi > 51.18 {
+		t.Errorf("chi-square = %g, counts %v", chi, counts)
+	}
+}
+
+func TestRand_WeightedChoice(t *testing.T) {
+	r := New(NewXoshiro256(2))
+	weights := []float64{1, 0, 3, 6}
+	counts := make([]int, len(weights))
+	const n = 100000
+	for i := 0; i < n; i++ {
+		counts[r.WeightedChoice(weights)]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("picked a 0 weight %d times", counts[1])
+	}
+	for i, w := range weights {
+		got, want := float64(counts[i])/n, w/10
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("index %d picked %g of the time, want %g", i, got, want)
+		}
+	}
+}
+
+func TestRand_Panics(t *testing.T) {
+	r := New(NewSplitMix64(1))
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "Intn 0", f: func() { r.Intn(0) }},
+		{name: "IntNM", f: func() { r.IntNM(1, 1) }},
+		{name: "Float64NM", f: func() { r.Float64NM(2, 1) }},
+		{name: "Choice 0", f: func() { r.Choice(0) }},
+		{name: "no weights", f: func() { r.WeightedChoice(nil) }},
+		{name: "negative weight", f: func() { r.WeightedChoice([]float64{1, -1}) }},
+		{name: "zero weights", f: func() { r.WeightedChoice([]float64{0, 0}) }},
+		{name: "Poisson negative", f: func() { r.Poisson(-1) }},
+		{name: "Poisson NaN", f: func() { r.Poisson(math.NaN()) }},
+		{name: "Poisson Inf", f: func() { r.Poisson(math.Inf(1)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	Seed(1)
+	a := []float64{Float64NM(0, 1), Float64NM(0, 1)}
+	Seed(1)
+	b := []float64{Float64NM(0, 1), Float64NM(0, 1)}
+	if a[0] != b[0] || a[1] != b[1] {
+		t.Errorf("%v != %v", a, b)
+	}
+}
+
+func BenchmarkRand_Float64(b *testing.B) {
+	r := New(NewXoshiro256(1))
+	for i := 0; i < b.N; i++ {
+		r.Float64()
+	}
+}
@@ -0,0 +1,279 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// Sequence is a low-discrepancy (quasi-random) sequence of points in the
+// unit hypercube [0,1)^Dims(). The points fill space more evenly than
+// random points do, which makes Monte Carlo integration converge faster
+// and gives nicer sample placement.
+type Sequence interface {
+	// Dims gets the number of dimensions of each point.
+	Dims() int
+	// Next fills dst[:Dims()] with the next point.
+	Next(dst []float64)
+	// Reset starts the sequence over from the beginning.
+	Reset()
+}
+
+// Halton is the Halton sequence, which uses the radical inverse of the
+// point's index in a different base for each dimension.
+//
+// See: https://en.wikipedia.org/wiki/Halton_sequence
+type Halton struct {
+	bases []int
+	perms [][][]int // [dim][digit position] random digit permutation, or nil
+	index uint64
+}
+
+// HaltonBases gets the first n primes, which are the usual bases for the
+// Halton sequence.
+func HaltonBases(n int) []int {
+	primes := make([]int, 0, n)
+	for c := 2; len(primes) < n; c++ {
+		prime := true
+		for _, p := range primes {
+			if p*p > c {
+				break
+			}
+			if c%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			primes = append(primes, c)
+		}
+	}
+	return primes
+}
+
+// NewHalton creates a Halton sequence with one dimension per base. Bases
+// should be coprime, eg HaltonBases(). Panics if any base is < 2.
+func NewHalton(bases ...int) *Halton {
+	for _, b := range bases {
+		if b < 2 {
+			panic(fmt.Errorf("Invalid params: base %d not >= 2", b))
+		}
+	}
+	return &Halton{bases: append([]int(nil), bases...), index: 1}
+}
+
+// NewScrambledHalton creates a Halton sequence whose digits are scrambled
+// by random permutations, which breaks up the strong correlation between
+// dimensions with large bases.
+//
+// See: https://en.wikipedia.org/wiki/Halton_sequence#Enhanced_versions
+func NewScrambledHalton(seed int64, bases ...int) *Halton {
+	h := NewHalton(bases...)
+	r := New(NewXoshiro256(uint64(seed)))
+	h.perms = make([][][]int, len(bases))
+	for d, b := range bases {
+		// one permutation for each digit that affects a float64
+		ndigits := int(math.Ceil(53 / math.Log2(float64(b))))
+		h.perms[d] = make([][]int, ndigits)
+		for i := range h.perms[d] {
+			h.perms[d][i] = r.Perm(b)
+		}
+	}
+	return h
+}
+
+// Dims gets the number of dimensions of each point.
+func (h *Halton) Dims() int {
+	return len(h.bases)
+}
+
+// Next fills dst[:Dims()] with the next point.
+func (h *Halton) Next(dst []float64) {
+	for d, b := range h.bases {
+		if h.perms == nil {
+			dst[d] = radicalInverse(h.index, b)
+		} else {
+			dst[d] = scrambledRadicalInverse(h.index, b, h.perms[d])
+		}
+	}
+	h.index++
+}
+
+// Reset starts the sequence over from the beginning.
+func (h *Halton) Reset() {
+	h.index = 1 // skip 0, which is the origin in every base
+}
+
+// reflects the base b digits of i about the "decimal" point.
+func radicalInverse(i uint64, b int) float64 {
+	base := uint64(b)
+	inv := 1 / float64(b)
+	f, result := inv, 0.0
+	for i > 0 {
+		result += float64(i%base) * f
+		i /= base
+		f *= inv
+	}
+	return result
+}
+
+// radicalInverse, but each digit is permuted. all the digits that can
+// affect a float64 are permuted, including leading 0s.
+func scrambledRadicalInverse(i uint64, b int, perms [][]int) float64 {
+	base := uint64(b)
+	inv := 1 / float64(b)
+	f, result := inv, 0.0
+	for _, perm := range perms {
+		result += float64(perm[i%base]) * f
+		i /= base
+		f *= inv
+	}
+	return math.Min(result, math.Nextafter(1, 0))
+}
+
+// Sobol is the Sobol sequence, using Joe and Kuo's direction numbers for
+// up to 16 dimensions. The first 2^m points are perfectly stratified in
+// each dimension. The first point is the origin.
+//
+// See: https://web.maths.unsw.edu.au/~fkuo/sobol/
+type Sobol struct {
+	dirs  [][32]uint32 // direction numbers for each dimension
+	x     []uint32
+	index uint32
+}
+
+// SobolMaxDims is the largest number of dimensions Sobol supports.
+const SobolMaxDims = 16
+
+// primitive polynomials and initial direction numbers for dimensions 2
+// to 16, from new-joe-kuo-6.21201. dimension 1 is special.
+var sobolParams = []struct {
+	s, a uint32
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+}
+
+// NewSobol creates a Sobol sequence with the given number of dimensions.
+// Panics if dims is not in [1,SobolMaxDims].
+func NewSobol(dims int) *Sobol {
+	if dims < 1 || dims > SobolMaxDims {
+		panic(fmt.Errorf("Invalid params: dims %d not in [1,%d]", dims, SobolMaxDims))
+	}
+	s := &Sobol{dirs: make([][32]uint32, dims), x: make([]uint32, dims)}
+
+	// first dimension is just the van der corput sequence in base 2
+	for i := range s.dirs[0] {
+		s.dirs[0][i] = 1 << uint(31-i)
+	}
+
+	for d := 1; d < dims; d++ {
+		p := sobolParams[d-1]
+		v := &s.dirs[d]
+		for i := uint32(0); i < p.s; i++ {
+			v[i] = p.m[i] << (31 - i)
+		}
+		for i := p.s; i < 32; i++ {
+			v[i] = v[i-p.s] ^ (v[i-p.s] >> p.s)
+			for k := uint32(1); k < p.s; k++ {
+				v[i] ^= ((p.a >> (p.s - 1 - k)) & 1) * v[i-k]
+			}
+		}
+	}
+	return s
+}
+
+// Dims gets the number of dimensions of each point.
+func (s *Sobol) Dims() int {
+	return len(s.dirs)
+}
+
+// Next fills dst[:Dims()] with the next point. The sequence repeats
+// after 2^32 points.
+func (s *Sobol) Next(dst []float64) {
+	for d, x := range s.x {
+		dst[d] = float64(x) / (1 << 32)
+	}
+
+	// gray code order: flip the direction number of the lowest 0 bit
+	c := 0
+	for i := s.index; i&1 == 1; i >>= 1 {
+		c++
+	}
+	if c < 32 {
+		for d := range s.x {
+			s.x[d] ^= s.dirs[d][c]
+		}
+	}
+	s.index++
+}
+
+// Reset starts the sequence over from the beginning.
+func (s *Sobol) Reset() {
+	s.index = 0
+	for d := range s.x {
+		s.x[d] = 0
+	}
+}
+
+// RSequence is Martin Roberts' "R-sequence", an additive recurrence based on
+// the generalized golden ratio. It's very simple and fast, and works
+// well in any number of dimensions.
+//
+// See: http://extremelearning.com.au/unreasonable-effectiveness-of-quasirandom-sequences/
+type RSequence struct {
+	alpha []float64
+	seed  float64
+	index uint64
+}
+
+// NewRSequence creates an R-sequence with the given number of dimensions. The seed,
+// usually 0.5, offsets every point. Panics if dims < 1.
+func NewRSequence(dims int, seed float64) *RSequence {
+	if dims < 1 {
+		panic(fmt.Errorf("Invalid params: dims %d not >= 1", dims))
+	}
+	// phi is the positive root of x^(dims+1) = x + 1. use newton's method.
+	phi := 2.0
+	for i := 0; i < 30; i++ {
+		phi -= (math.Pow(phi, float64(dims+1)) - phi - 1) / (float64(dims+1)*math.Pow(phi, float64(dims)) - 1)
+	}
+	r := &RSequence{alpha: make([]float64, dims), seed: seed, index: 1}
+	for i := range r.alpha {
+		r.alpha[i] = math.Mod(math.Pow(1/phi, float64(i+1)), 1)
+	}
+	return r
+}
+
+// Dims gets the number of dimensions of each point.
+func (r *RSequence) Dims() int {
+	return len(r.alpha)
+}
+
+// Next fills dst[:Dims()] with the next point.
+func (r *RSequence) Next(dst []float64) {
+	n := float64(r.index)
+	for d, a := range r.alpha {
+		v := r.seed + n*a
+		dst[d] = v - math.Floor(v)
+	}
+	r.index++
+}
+
+// Reset starts the sequence over from the beginning.
+func (r *RSequence) Reset() {
+	r.index = 1
+}
@@ -0,0 +1,158 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+// L2-star discrepancy of the points, using Warnock's formula. Lower is
+// more evenly spread.
+func l2StarDiscrepancy(pts [][]float64) float64 {
+	n := float64(len(pts))
+	dims := len(pts[0])
+	sum1 := 0.0
+	for _, p := range pts {
+		prod := 1.0
+		for _, x := range p {
+			prod *= 1 - x*x
+		}
+		sum1 += prod
+	}
+	sum2 := 0.0
+	for _, p := range pts {
+		for _, q := range pts {
+			prod := 1.0
+			for k := range p {
+				prod *= 1 - math.Max(p[k], q[k])
+			}
+			sum2 += prod
+		}
+	}
+	d := math.Pow(3, -float64(dims)) - math.Pow(2, 1-float64(dims))/n*sum1 + sum2/(n*n)
+	return math.Sqrt(d)
+}
+
+// takes n points from the sequence.
+func take(s Sequence, n int) [][]float64 {
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = make([]float64, s.Dims())
+		s.Next(pts[i])
+	}
+	return pts
+}
+
+func TestSequences(t *testing.T) {
+	const n, dims = 512, 3
+	// expected discrepancy of uniformly random points
+	randomD := math.Sqrt((math.Pow(2, -dims) - math.Pow(3, -dims)) / n)
+
+	tests := []struct {
+		name string
+		seq  Sequence
+	}{
+		{name: "halton", seq: NewHalton(HaltonBases(dims)...)},
+		{name: "scrambled halton", seq: NewScrambledHalton(1, HaltonBases(dims)...)},
+		{name: "sobol", seq: NewSobol(dims)},
+		{name: "r sequence", seq: NewRSequence(dims, 0.5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pts := take(tt.seq, n)
+			for _, p := range pts {
+				for _, x := range p {
+					if x < 0 || x >= 1 {
+						t.Fatalf("%v not in unit cube", p)
+					}
+				}
+			}
+
+			d := l2StarDiscrepancy(pts)
+			t.Logf("discrepancy %g, random %g", d, randomD)
+			if d > randomD/2 {
+				t.Errorf("discrepancy %g not much better than random %g", d, randomD)
+			}
+
+			tt.seq.Reset()
+			again := take(tt.seq, n)
+			for i := range pts {
+				for k := range pts[i] {
+					if pts[i][k] != again[i][k] {
+						t.Fatalf("point %d differs after Reset: %v != %v", i, pts[i], again[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHalton(t *testing.T) {
+	h := NewHalton(2, 3)
+	want := [][]float64{{1. / 2, 1. / 3}, {1. / 4, 2. / 3}, {3. / 4, 1. / 9}, {1. / 8, 4. / 9}}
+	got := take(h, len(want))
+	for i := range want {
+		for k := range want[i] {
+			if math.Abs(got[i][k]-want[i][k]) > 1e-15 {
+				t.Errorf("point %d = %v, want %v", i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestHaltonBases(t *testing.T) {
+	want := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+	for i, p := range HaltonBases(10) {
+		if p != want[i] {
+			t.Errorf("prime %d = %d, want %d", i, p, want[i])
+		}
+	}
+}
+
+func TestSobol_Stratified(t *testing.T) {
+	// the first 2^m points of each dimension are exactly the multiples of 2^-m
+	const m = 8
+	pts := take(NewSobol(SobolMaxDims), 1<<m)
+	for d := 0; d < SobolMaxDims; d++ {
+		seen := make([]bool, 1<<m)
+		for _, p := range pts {
+			k := p[d] * (1 << m)
+			if k != math.Floor(k) || seen[int(k)] {
+				t.Fatalf("dimension %d not stratified at %g", d, p[d])
+			}
+			seen[int(k)] = true
+		}
+	}
+
+	// dimensions 1 and 2 form a (0,m,2)-net: every dyadic box of
+	// area 2^-m holds exactly 1 point.
+	for a := 0; a <= m; a++ {
+		counts := map[[2]int]int{}
+		for _, p := range pts {
+			counts[[2]int{int(p[0] * float64(int(1)<<a)), int(p[1] * float64(int(1)<<(m-a)))}]++
+		}
+		for box, c := range counts {
+			if c != 1 {
+				t.Fatalf("box %v of size 2^-%d x 2^-%d has %d points", box, a, m-a, c)
+			}
+		}
+	}
+}
+
+func TestSobol_Known(t *testing.T) {
+	// first points of the 2D sobol sequence
+	want := [][]float64{{0, 0}, {0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}, {0.875, 0.875}}
+	got := take(NewSobol(2), len(want))
+	for i := range want {
+		if got[i][0] != want[i][0] || got[i][1] != want[i][1] {
+			t.Errorf("point %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func BenchmarkSobol(b *testing.B) {
+	s := NewSobol(4)
+	dst := make([]float64, 4)
+	for i := 0; i < b.N; i++ {
+		s.Next(dst)
+	}
+}
@@ -0,0 +1,108 @@
+package rand
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Source is a source of uniformly distributed random bits. Unlike the
+// sources in math/rand, the implementations here are fully specified, so
+// a given seed produces the same sequence on every version of Go.
+type Source interface {
+	Uint64() uint64
+}
+
+// SplitMix64 is a very fast generator with 64 bits of state. It's mostly
+// useful for seeding other generators.
+//
+// See: https://prng.di.unimi.it/splitmix64.c
+type SplitMix64 struct {
+	state uint64
+}
+
+// NewSplitMix64 creates a SplitMix64 with the given seed.
+func NewSplitMix64(seed uint64) *SplitMix64 {
+	return &SplitMix64{state: seed}
+}
+
+// Uint64 gets the next 64 random bits.
+func (s *SplitMix64) Uint64() uint64 {
+	s.state += 0x9E3779B97F4A7C15
+	return mix64(s.state)
+}
+
+// PCG is the PCG-XSH-RR generator, which has 64 bits of state and produces
+// 32 bits at a time. Different streams with the same seed are independent.
+//
+// See: https://www.pcg-random.org/
+type PCG struct {
+	state, inc uint64
+}
+
+// NewPCG creates a PCG with the given seed and stream.
+func NewPCG(seed, stream uint64) *PCG {
+	p := &PCG{inc: stream<<1 | 1}
+	p.Uint32()
+	p.state += seed
+	p.Uint32()
+	return p
+}
+
+// Uint32 gets the next 32 random bits.
+func (p *PCG) Uint32() uint32 {
+	old := p.state
+	p.state = old*6364136223846793005 + p.inc
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := int(old >> 59)
+	return bits.RotateLeft32(xorshifted, -rot)
+}
+
+// Uint64 gets the next 64 random bits, made from two calls to Uint32.
+func (p *PCG) Uint64() uint64 {
+	return uint64(p.Uint32())<<32 | uint64(p.Uint32())
+}
+
+// Xoshiro256 is the xoshiro256** generator, which has 256 bits of state.
+//
+// See: https://prng.di.unimi.it/xoshiro256starstar.c
+type Xoshiro256 struct {
+	s [4]uint64
+}
+
+// NewXoshiro256 creates a Xoshiro256 whose state is filled by a SplitMix64
+// with the given seed, as recommended by its authors.
+func NewXoshiro256(seed uint64) *Xoshiro256 {
+	sm := NewSplitMix64(seed)
+	x := new(Xoshiro256)
+	for i := range x.s {
+		x.s[i] = sm.Uint64()
+	}
+	return x
+}
+
+// Uint64 gets the next 64 random bits.
+func (x *Xoshiro256) Uint64() uint64 {
+	s := &x.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+// makes a Source safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src Source
+}
+
+func (l *lockedSource) Uint64() (n uint64) {
+	l.mu.Lock()
+	n = l.src.Uint64()
+	l.mu.Unlock()
+	return
+}
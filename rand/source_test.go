@@ -0,0 +1,39 @@
+package rand
+
+import "testing"
+
+func TestSplitMix64(t *testing.T) {
+	// reference values from splitmix64.c
+	s := NewSplitMix64(1234567)
+	want := []uint64{6457827717110365317, 3203168211198807973, 9817491932198370423,
+		4593380528125082431, 16408922859458223821}
+	for i, w := range want {
+		if got := s.Uint64(); got != w {
+			t.Errorf("%d: got %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestPCG(t *testing.T) {
+	// reference values from pcg32-demo.c
+	p := NewPCG(42, 54)
+	want := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	for i, w := range want {
+		if got := p.Uint32(); got != w {
+			t.Errorf("%d: got %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestXoshiro256(t *testing.T) {
+	// values checked against the reference xoshiro256starstar.c,
+	// seeded by splitmix64.c with 1
+	x := NewXoshiro256(1)
+	want := []uint64{12966619160104079557, 9600361134598540522, 10590380919521690900,
+		7218738570589545383, 12860671823995680371}
+	for i, w := range want {
+		if got := x.Uint64(); got != w {
+			t.Errorf("%d: got %d, want %d", i, got, w)
+		}
+	}
+}
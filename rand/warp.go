@@ -0,0 +1,177 @@
+package rand
+
+// VectorField3D is implemented by anything that produces a 3D vector at
+// a point in 3D space.
+type VectorField3D interface {
+	Vector(x, y, z float64) (vx, vy, vz float64)
+}
+
+// VectorField2D is implemented by anything that produces a 2D vector at
+// a point in 2D space.
+type VectorField2D interface {
+	Vector(x, y float64) (vx, vy float64)
+}
+
+// VectorField3DFunc allows an ordinary function to be used as a VectorField3D.
+type VectorField3DFunc func(x, y, z float64) (vx, vy, vz float64)
+
+// Vector calls f(x, y, z).
+func (f VectorField3DFunc) Vector(x, y, z float64) (vx, vy, vz float64) {
+	return f(x, y, z)
+}
+
+// VectorField2DFunc allows an ordinary function to be used as a VectorField2D.
+type VectorField2DFunc func(x, y float64) (vx, vy float64)
+
+// Vector calls f(x, y).
+func (f VectorField2DFunc) Vector(x, y float64) (vx, vy float64) {
+	return f(x, y)
+}
+
+// offsets used to sample a scalar noise source several times to make
+// a vector. they're arbitrary but far apart so the components aren't
+// correlated.
+var fieldOffsets = [3][3]float64{
+	{0, 0, 0},
+	{5.2, 1.3, 9.7},
+	{13.1, 7.9, 2.8},
+}
+
+// NoiseField3D makes a vector field out of a scalar noise source by sampling
+// it at 3 different offsets, one for each component.
+func NoiseField3D(n Noise3D) VectorField3D {
+	return VectorField3DFunc(func(x, y, z float64) (vx, vy, vz float64) {
+		o := &fieldOffsets
+		return n.Noise(x+o[0][0], y+o[0][1], z+o[0][2]),
+			n.Noise(x+o[1][0], y+o[1][1], z+o[1][2]),
+			n.Noise(x+o[2][0], y+o[2][1], z+o[2][2])
+	})
+}
+
+// NoiseField2D makes a vector field out of a scalar noise source by sampling
+// it at 2 different offsets, one for each component.
+func NoiseField2D(n Noise2D) VectorField2D {
+	return VectorField2DFunc(func(x, y float64) (vx, vy float64) {
+		o := &fieldOffsets
+		return n.Noise(x+o[0][0], y+o[0][1]),
+			n.Noise(x+o[1][0], y+o[1][1])
+	})
+}
+
+// Warp distorts the domain of a noise source with a vector field before
+// sampling it, which gives organic, swirly results. With one iteration it
+// computes
+//
+//	Source(p + Strength*Displace(p))
+//
+// and with two
+//
+//	Source(p + Strength*Displace(p + Strength*Displace(p)))
+//
+// and so on. Using a Fractal as both the source and (via NoiseField3D) the
+// displacement gives Inigo Quilez's fbm(p + fbm(p)).
+//
+// See: https://iquilezles.org/articles/warp/
+type Warp struct {
+	Source     Noise3D
+	Displace   VectorField3D
+	Strength   float64
+	Iterations int
+}
+
+// NewWarp creates a Warp that displaces source by a field made from
+// the displace noise with NoiseField3D. Strength is how far, in the source's
+// units, points are moved. Iterations is how many times the displacement
+// is applied, usually 1 or 2.
+func NewWarp(source, displace Noise3D, strength float64, iterations int) *Warp {
+	return &Warp{
+		Source:     source,
+		Displace:   NoiseField3D(displace),
+		Strength:   strength,
+		Iterations: iterations,
+	}
+}
+
+// Noise gets the warped noise value at (x, y, z).
+func (w *Warp) Noise(x, y, z float64) float64 {
+	qx, qy, qz := x, y, z
+	for i := 0; i < w.Iterations; i++ {
+		dx, dy, dz := w.Displace.Vector(qx, qy, qz)
+		qx, qy, qz = x+w.Strength*dx, y+w.Strength*dy, z+w.Strength*dz
+	}
+	return w.Source.Noise(qx, qy, qz)
+}
+
+// Warp2D is the same as Warp but for 2D sources and fields.
+type Warp2D struct {
+	Source     Noise2D
+	Displace   VectorField2D
+	Strength   float64
+	Iterations int
+}
+
+// NewWarp2D creates a Warp2D. See NewWarp.
+func NewWarp2D(source, displace Noise2D, strength float64, iterations int) *Warp2D {
+	return &Warp2D{
+		Source:     source,
+		Displace:   NoiseField2D(displace),
+		Strength:   strength,
+		Iterations: iterations,
+	}
+}
+
+// Noise gets the warped noise value at (x, y).
+func (w *Warp2D) Noise(x, y float64) float64 {
+	qx, qy := x, y
+	for i := 0; i < w.Iterations; i++ {
+		dx, dy := w.Displace.Vector(qx, qy)
+		qx, qy = x+w.Strength*dx, y+w.Strength*dy
+	}
+	return w.Source.Noise(qx, qy)
+}
+
+// default step used for finite differences in the curl functions.
+const curlEpsilon = 1e-4
+
+// Curl2D makes a divergence-free 2D vector field from a scalar potential
+// (usually a noise function), suitable for advecting particles so that they
+// swirl around without bunching up or spreading out. The field is
+//
+//	(dP/dy, -dP/dx)
+//
+// computed with central differences.
+//
+// See: https://www.cs.ubc.ca/~rbridson/docs/bridson-siggraph2007-curlnoise.pdf
+func Curl2D(potential Noise2D) VectorField2D {
+	return VectorField2DFunc(func(x, y float64) (vx, vy float64) {
+		const e = curlEpsilon
+		dpdx := (potential.Noise(x+e, y) - potential.Noise(x-e, y)) / (2 * e)
+		dpdy := (potential.Noise(x, y+e) - potential.Noise(x, y-e)) / (2 * e)
+		return dpdy, -dpdx
+	})
+}
+
+// Curl3D makes a divergence-free 3D vector field from a vector potential.
+// A suitable potential can be made from scalar noise with NoiseField3D.
+// The field is the curl of the potential, computed with central differences.
+//
+// See: https://www.cs.ubc.ca/~rbridson/docs/bridson-siggraph2007-curlnoise.pdf
+func Curl3D(potential VectorField3D) VectorField3D {
+	return VectorField3DFunc(func(x, y, z float64) (vx, vy, vz float64) {
+		const e = curlEpsilon
+		// partial derivatives of the components of the potential
+		_, y1, z1 := potential.Vector(x+e, y, z)
+		_, y0, z0 := potential.Vector(x-e, y, z)
+		dydx, dzdx := (y1-y0)/(2*e), (z1-z0)/(2*e)
+
+		x1, _, z1 := potential.Vector(x, y+e, z)
+		x0, _, z0 := potential.Vector(x, y-e, z)
+		dxdy, dzdy := (x1-x0)/(2*e), (z1-z0)/(2*e)
+
+		x1, y1, _ = potential.Vector(x, y, z+e)
+		x0, y0, _ = potential.Vector(x, y, z-e)
+		dxdz, dydz := (x1-x0)/(2*e), (y1-y0)/(2*e)
+
+		return dzdy - dydz, dxdz - dzdx, dydx - dxdy
+	})
+}
@@ -0,0 +1,68 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWarp(t *testing.T) {
+	FillPermutation(1)
+	perlin := Noise3DFunc(Noise3)
+
+	// zero strength should be the same as the source
+	w := NewWarp(perlin, perlin, 0, 2)
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.31, float64(i)*0.17, 0.5
+		if got, want := w.Noise(x, y, z), Noise3(x, y, z); got != want {
+			t.Errorf("zero strength: got %g, want %g", got, want)
+		}
+	}
+
+	// one iteration is source(p + strength*field(p))
+	w = NewWarp(perlin, perlin, 4, 1)
+	field := NoiseField3D(perlin)
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.31, float64(i)*0.17, 0.5
+		dx, dy, dz := field.Vector(x, y, z)
+		want := Noise3(x+4*dx, y+4*dy, z+4*dz)
+		if got := w.Noise(x, y, z); got != want {
+			t.Errorf("one iteration: got %g, want %g", got, want)
+		}
+	}
+}
+
+func TestCurl2D_DivergenceFree(t *testing.T) {
+	FillPermutation(1)
+	curl := Curl2D(Noise2DFunc(Noise2))
+	const h = 1e-3
+	for i := 0; i < 100; i++ {
+		x, y := float64(i)*0.37+0.05, float64(i)*0.23+0.05
+		vx1, _ := curl.Vector(x+h, y)
+		vx0, _ := curl.Vector(x-h, y)
+		_, vy1 := curl.Vector(x, y+h)
+		_, vy0 := curl.Vector(x, y-h)
+		div := (vx1-vx0)/(2*h) + (vy1-vy0)/(2*h)
+		if math.Abs(div) > 1e-3 {
+			t.Errorf("divergence at (%g,%g) = %g", x, y, div)
+		}
+	}
+}
+
+func TestCurl3D_DivergenceFree(t *testing.T) {
+	FillPermutation(1)
+	curl := Curl3D(NoiseField3D(Noise3DFunc(Noise3)))
+	const h = 1e-3
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.37+0.05, float64(i)*0.23+0.05, float64(i)*0.11+0.05
+		vx1, _, _ := curl.Vector(x+h, y, z)
+		vx0, _, _ := curl.Vector(x-h, y, z)
+		_, vy1, _ := curl.Vector(x, y+h, z)
+		_, vy0, _ := curl.Vector(x, y-h, z)
+		_, _, vz1 := curl.Vector(x, y, z+h)
+		_, _, vz0 := curl.Vector(x, y, z-h)
+		div := (vx1-vx0)/(2*h) + (vy1-vy0)/(2*h) + (vz1-vz0)/(2*h)
+		if math.Abs(div) > 1e-3 {
+			t.Errorf("divergence at (%g,%g,%g) = %g", x, y, z, div)
+		}
+	}
+}
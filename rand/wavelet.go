@@ -0,0 +1,322 @@
+package rand
+
+import (
+	"fmt"
+	"math"
+)
+
+// analysis (downsampling) filter coefficients from the paper, centered on
+// index waveletARad.
+var waveletA = [2 * waveletARad]float64{
+	0.000334, -0.001528, 0.000410, 0.003545, -0.000938, -0.008233, 0.002172, 0.019120,
+	-0.005040, -0.044412, 0.011655, 0.103311, -0.025936, -0.243780, 0.033979, 0.655340,
+	0.655340, 0.033979, -0.243780, -0.025936, 0.103311, 0.011655, -0.044412, -0.005040,
+	0.019120, 0.002172, -0.008233, -0.000938, 0.003546, 0.000410, -0.001528, 0.000334,
+}
+
+const waveletARad = 16
+
+// refinement (upsampling) filter coefficients, centered on index 2.
+var waveletP = [4]float64{0.25, 0.75, 0.75, 0.25}
+
+// the variance of a single band of each kind of wavelet noise, used to
+// normalize Octaves. the 3D values are from the paper, the 2D one was
+// measured the same way.
+const (
+	waveletVariance2D        = 0.266
+	waveletVariance3D        = 0.210
+	waveletVarianceProjected = 0.296
+)
+
+// x mod n, in [0,n).
+func mod(x, n int) int {
+	m := x % n
+	if m < 0 {
+		m += n
+	}
+	return m
+}
+
+// downsamples the n values of from (every stride'th element) to the n/2
+// values of to.
+func waveletDownsample(from, to []float64, n, stride int) {
+	for i := 0; i < n/2; i++ {
+		sum := 0.0
+		for k := 2*i - waveletARad; k < 2*i+waveletARad; k++ {
+			sum += waveletA[k-2*i+waveletARad] * from[mod(k, n)*stride]
+		}
+		to[i*stride] = sum
+	}
+}
+
+// upsamples the n/2 values of from (every stride'th element) to the n values
+// of to.
+func waveletUpsample(from, to []float64, n, stride int) {
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for k := i / 2; k <= i/2+1; k++ {
+			sum += waveletP[i-2*k+2] * from[mod(k, n/2)*stride]
+		}
+		to[i*stride] = sum
+	}
+}
+
+// makes a tile of wavelet noise coefficients with the given number of
+// dimensions (2 or 3) and size along each. size must be even.
+func makeWaveletTile(seed int64, size, dims int) []float64 {
+	total := 1
+	for i := 0; i < dims; i++ {
+		total *= size
+	}
+
+	// 1. fill the tile with random numbers
+	r := New(NewXoshiro256(uint64(seed)))
+	noise := make([]float64, total)
+	for i := range noise {
+		noise[i] = r.Normal(0, 1)
+	}
+
+	// 2 and 3. downsample and upsample each row along each axis
+	temp1, temp2 := make([]float64, total), make([]float64, total)
+	copy(temp2, noise)
+	for axis, stride := 0, 1; axis < dims; axis, stride = axis+1, stride*size {
+		for start := 0; start < total; start++ {
+			if (start/stride)%size != 0 {
+				continue // not the first element of a row along this axis
+			}
+			waveletDownsample(temp2[start:], temp1[start:], size, stride)
+			waveletUpsample(temp1[start:], temp2[start:], size, stride)
+		}
+	}
+
+	// 4. subtract the coarse scale part, leaving the fine scale
+	for i := range noise {
+		noise[i] -= temp2[i]
+	}
+
+	// avoid a difference in variance between even and odd coefficients by
+	// adding a copy of the noise offset by an odd amount
+	offset := size / 2
+	if offset%2 == 0 {
+		offset++
+	}
+	for i := range temp1 {
+		j, stride := 0, 1
+		for axis := 0; axis < dims; axis++ {
+			c := (i / stride) % size
+			j += mod(c+offset, size) * stride
+			stride *= size
+		}
+		temp1[i] = noise[j]
+	}
+	for i := range noise {
+		noise[i] += temp1[i]
+	}
+	return noise
+}
+
+// the quadratic B-spline weights of the 3 coefficients around p, and the
+// index of the middle one.
+func waveletWeights(p float64) (mid int, w [3]float64) {
+	mid = int(math.Ceil(p - 0.5))
+	t := float64(mid) - (p - 0.5)
+	w[0] = t * t / 2
+	w[2] = (1 - t) * (1 - t) / 2
+	w[1] = 1 - w[0] - w[2]
+	return
+}
+
+// makes the tile size even and at least 4.
+func waveletTileSize(size int) int {
+	if size < 4 {
+		panic(fmt.Errorf("Invalid params: tile size %d not >= 4", size))
+	}
+	return size + size%2
+}
+
+// sums the bands of a wavelet noise function. band b is f at 2^(first+b+1)
+// times p, with weight w[b]. bands finer than the footprint are skipped, and
+// the result is normalized to a variance of about 1.
+func waveletOctaves(f func(scale float64) float64, footprint float64, first int, weights []float64, variance float64) float64 {
+	s := math.Inf(-1)
+	if footprint > 0 {
+		s = math.Log2(footprint)
+	}
+	result, sum := 0.0, 0.0
+	for b, w := range weights {
+		if s+float64(first+b) < 0 {
+			result += w * f(math.Ldexp(1, first+b+1))
+		}
+		sum += w * w
+	}
+	if sum == 0 {
+		return 0
+	}
+	return result / math.Sqrt(sum*variance)
+}
+
+// Wavelet2D is 2D wavelet noise. It tiles with a period of its tile size,
+// and it's safe for concurrent use.
+//
+// Wavelet noise is made from a tile of random coefficients from which the
+// coarser half of the frequencies have been removed, so each band (octave)
+// of it is band-limited. Unlike Perlin noise, bands that are too fine for
+// the sampling rate can simply be left out (see Octaves), so the noise doesn't
+// alias or shimmer when it's minified, such as when a camera zooms out.
+//
+// See: "Wavelet Noise" (R. L. Cook and T. DeRose, SIGGRAPH 2005)
+type Wavelet2D struct {
+	size int
+	tile []float64
+}
+
+// NewWavelet2D creates wavelet noise with a size x size tile of coefficients.
+// The paper uses 128; small tiles repeat visibly. The size is rounded up to
+// be even, and panics if it's less than 4.
+func NewWavelet2D(seed int64, size int) *Wavelet2D {
+	size = waveletTileSize(size)
+	return &Wavelet2D{size: size, tile: makeWaveletTile(seed, size, 2)}
+}
+
+// Noise gets a single band of the noise at (x, y). Its features are about
+// 1 unit apart.
+func (w *Wavelet2D) Noise(x, y float64) float64 {
+	n := w.size
+	mx, wx := waveletWeights(x)
+	my, wy := waveletWeights(y)
+	result := 0.0
+	for fy := -1; fy <= 1; fy++ {
+		row := mod(my+fy, n) * n
+		for fx := -1; fx <= 1; fx++ {
+			result += wx[fx+1] * wy[fy+1] * w.tile[row+mod(mx+fx, n)]
+		}
+	}
+	return result
+}
+
+// Octaves sums len(weights) bands of the noise at (x, y), where band b has
+// features 2^-(first+b+1) units apart and is weighted by weights[b].
+//
+// Footprint is the size, in noise units, of the area a sample covers (eg a
+// pixel). Bands finer than that are left out, so there's no aliasing. Use
+// 0 to include every band. The result is normalized to a variance of about 1.
+func (w *Wavelet2D) Octaves(x, y, footprint float64, first int, weights []float64) float64 {
+	return waveletOctaves(func(scale float64) float64 {
+		return w.Noise(x*scale, y*scale)
+	}, footprint, first, weights, waveletVariance2D)
+}
+
+// Wavelet3D is 3D wavelet noise. It tiles with a period of its tile size,
+// and it's safe for concurrent use. See Wavelet2D.
+type Wavelet3D struct {
+	size int
+	tile []float64
+}
+
+// NewWavelet3D creates wavelet noise with a size x size x size tile of
+// coefficients. The paper uses 128, which is 2M coefficients; 32 or 64 are
+// often enough. See NewWavelet2D.
+func NewWavelet3D(seed int64, size int) *Wavelet3D {
+	size = waveletTileSize(size)
+	return &Wavelet3D{size: size, tile: makeWaveletTile(seed, size, 3)}
+}
+
+// Noise gets a single band of the noise at (x, y, z).
+func (w *Wavelet3D) Noise(x, y, z float64) float64 {
+	n := w.size
+	mx, wx := waveletWeights(x)
+	my, wy := waveletWeights(y)
+	mz, wz := waveletWeights(z)
+	result := 0.0
+	for fz := -1; fz <= 1; fz++ {
+		layer := mod(mz+fz, n) * n * n
+		for fy := -1; fy <= 1; fy++ {
+			row := layer + mod(my+fy, n)*n
+			wyz := wy[fy+1] * wz[fz+1]
+			for fx := -1; fx <= 1; fx++ {
+				result += wx[fx+1] * wyz * w.tile[row+mod(mx+fx, n)]
+			}
+		}
+	}
+	return result
+}
+
+// Projected gets a single band of the noise at (x, y, z), projected along
+// the normal of a surface through that point. Slices of 3D noise on a
+// surface aren't band-limited in 2D, but the projection is, so use it to
+// texture surfaces in 3D. The normal doesn't need to be normalized.
+func (w *Wavelet3D) Projected(x, y, z float64, normal [3]float64) float64 {
+	n := w.size
+	p := [3]float64{x, y, z}
+	l := math.Sqrt(normal[0]*normal[0] + normal[1]*normal[1] + normal[2]*normal[2])
+	if l == 0 {
+		return w.Noise(x, y, z)
+	}
+	for i := range normal {
+		normal[i] /= l
+	}
+
+	// bound the support of the basis functions along the normal
+	var lo, hi [3]int
+	for i := range p {
+		support := 3*math.Abs(normal[i]) + 3*math.Sqrt((1-normal[i]*normal[i])/2)
+		lo[i] = int(math.Ceil(p[i] - support))
+		hi[i] = int(math.Floor(p[i] + support))
+	}
+
+	result := 0.0
+	var c [3]int
+	for c[2] = lo[2]; c[2] <= hi[2]; c[2]++ {
+		for c[1] = lo[1]; c[1] <= hi[1]; c[1]++ {
+			for c[0] = lo[0]; c[0] <= hi[0]; c[0]++ {
+				dot := 0.0
+				for i := range p {
+					dot += normal[i] * (p[i] - float64(c[i]))
+				}
+				// the basis function at c moved halfway to p along the normal
+				weight := 1.0
+				for i := range p {
+					weight *= quadraticBSpline(float64(c[i]) + normal[i]*dot/2 - (p[i] - 1.5))
+					if weight == 0 {
+						break
+					}
+				}
+				if weight != 0 {
+					result += weight * w.tile[mod(c[2], n)*n*n+mod(c[1], n)*n+mod(c[0], n)]
+				}
+			}
+		}
+	}
+	return result
+}
+
+// the uniform quadratic B-spline basis function, with support [0,3].
+func quadraticBSpline(t float64) float64 {
+	switch {
+	case t <= 0 || t >= 3:
+		return 0
+	case t < 1:
+		return t * t / 2
+	case t < 2:
+		t1, t2 := t-1, 2-t
+		return 1 - (t1*t1+t2*t2)/2
+	default:
+		t3 := 3 - t
+		return t3 * t3 / 2
+	}
+}
+
+// Octaves sums bands of the noise at (x, y, z). See Wavelet2D.Octaves.
+func (w *Wavelet3D) Octaves(x, y, z, footprint float64, first int, weights []float64) float64 {
+	return waveletOctaves(func(scale float64) float64 {
+		return w.Noise(x*scale, y*scale, z*scale)
+	}, footprint, first, weights, waveletVariance3D)
+}
+
+// ProjectedOctaves sums bands of the projected noise at (x, y, z). See
+// Projected and Wavelet2D.Octaves.
+func (w *Wavelet3D) ProjectedOctaves(x, y, z float64, normal [3]float64, footprint float64, first int, weights []float64) float64 {
+	return waveletOctaves(func(scale float64) float64 {
+		return w.Projected(x*scale, y*scale, z*scale, normal)
+	}, footprint, first, weights, waveletVarianceProjected)
+}
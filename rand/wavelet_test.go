@@ -0,0 +1,171 @@
+package rand
+
+import (
+	"math"
+	"testing"
+)
+
+// fraction of the power of f(x, y), sampled over a 16 by 16 unit square,
+// at frequencies below maxFreq cycles per unit. uses a separable DFT.
+func lowPowerFraction(f func(x, y float64) float64, maxFreq float64) float64 {
+	const n, perUnit = 64, 4
+	re, im := make([]float64, n*n), make([]float64, n*n)
+	mean := 0.0
+	for i := range re {
+		re[i] = f(float64(i%n)/perUnit, float64(i/n)/perUnit)
+		mean += re[i] / (n * n)
+	}
+	for i := range re {
+		re[i] -= mean
+	}
+	// transform rows then columns
+	dft := func(start, stride int) {
+		var tr, ti [n]float64
+		for k := 0; k < n; k++ {
+			for j := 0; j < n; j++ {
+				s, c := math.Sincos(-2 * math.Pi * float64(k*j) / n)
+				x, y := re[start+j*stride], im[start+j*stride]
+				tr[k] += x*c - y*s
+				ti[k] += x*s + y*c
+			}
+		}
+		for k := 0; k < n; k++ {
+			re[start+k*stride], im[start+k*stride] = tr[k], ti[k]
+		}
+	}
+	for i := 0; i < n; i++ {
+		dft(i*n, 1)
+	}
+	for i := 0; i < n; i++ {
+		dft(i, n)
+	}
+
+	low, total := 0.0, 0.0
+	for i := range re {
+		kx, ky := i%n, i/n
+		if kx > n/2 {
+			kx -= n
+		}
+		if ky > n/2 {
+			ky -= n
+		}
+		p := re[i]*re[i] + im[i]*im[i]
+		total += p
+		if math.Hypot(float64(kx), float64(ky))*perUnit/n < maxFreq {
+			low += p
+		}
+	}
+	return low / total
+}
+
+func TestWavelet_BandLimited(t *testing.T) {
+	// a band of wavelet noise has features about 1 unit apart and very
+	// little power at lower frequencies, unlike perlin noise or a slice
+	// through 3D wavelet noise.
+	w2 := NewWavelet2D(1, 16)
+	w3 := NewWavelet3D(1, 16)
+	p := NewPerlin(1)
+	normal := [3]float64{0, 0, 1}
+	wavelet := lowPowerFraction(w2.Noise, 0.15)
+	projected := lowPowerFraction(func(x, y float64) float64 { return w3.Projected(x, y, 0.5, normal) }, 0.15)
+	slice := lowPowerFraction(func(x, y float64) float64 { return w3.Noise(x, y, 0.5) }, 0.15)
+	perlin := lowPowerFraction(func(x, y float64) float64 { return p.Noise(x, y, 0.5) }, 0.15)
+	t.Logf("power below 0.15 cycles/unit: 2D %.4f, projected %.4f, slice %.4f, perlin %.4f",
+		wavelet, projected, slice, perlin)
+	if wavelet > 0.01 || projected > perlin/3 || slice < perlin/2 {
+		t.Error("wavelet noise should have much less low frequency power than perlin")
+	}
+}
+
+func TestWavelet_Tiles(t *testing.T) {
+	w2 := NewWavelet2D(2, 16)
+	w3 := NewWavelet3D(2, 8)
+	for i := 0; i < 1000; i++ {
+		x, y, z := float64(i)*0.377, float64(i)*0.213, float64(i)*0.101
+		if a, b := w2.Noise(x, y), w2.Noise(x+16, y-32); !nearlyEqual(a, b) {
+			t.Fatalf("2D at (%g,%g): %g != %g", x, y, a, b)
+		}
+		if a, b := w3.Noise(x, y, z), w3.Noise(x-8, y, z+24); !nearlyEqual(a, b) {
+			t.Fatalf("3D at (%g,%g,%g): %g != %g", x, y, z, a, b)
+		}
+	}
+}
+
+func TestWavelet_Octaves(t *testing.T) {
+	w := NewWavelet2D(3, 64)
+	weights := []float64{1, 0.5, 0.25, 0.125}
+	norm := math.Sqrt((1 + 0.25 + 0.0625 + 0.015625) * waveletVariance2D)
+
+	// a quarter unit footprint leaves out the bands with features 1/8 and
+	// 1/16 units apart
+	x, y := 3.7, 1.2
+	want := (w.Noise(2*x, 2*y) + 0.5*w.Noise(4*x, 4*y)) / norm
+	if got := w.Octaves(x, y, 0.25, 0, weights); !nearlyEqual(got, want) {
+		t.Errorf("footprint 0.25: got %g, want %g", got, want)
+	}
+	if got := w.Octaves(x, y, 100, 0, weights); got != 0 {
+		t.Errorf("huge footprint: got %g, want 0", got)
+	}
+
+	// all bands should have a variance of about 1
+	w3 := NewWavelet3D(3, 32)
+	tests := []struct {
+		name  string
+		noise func(x, y, z float64) float64
+	}{
+		{"2D", func(x, y, z float64) float64 { return w.Octaves(x, y, 0, 0, weights) }},
+		{"3D", func(x, y, z float64) float64 { return w3.Octaves(x, y, z, 0, -2, weights) }},
+		{"projected", func(x, y, z float64) float64 {
+			return w3.ProjectedOctaves(x, y, z, [3]float64{1, 2, 3}, 0, -2, weights)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := make([]float64, 2000)
+			for i := range values {
+				values[i] = tt.noise(float64(i)*0.377, float64(i)*0.213, float64(i)*0.101)
+			}
+			if _, sd := meanStdDev(values); sd < 0.85 || 1.15 < sd {
+				t.Errorf("std dev %g, want about 1", sd)
+			}
+		})
+	}
+}
+
+func TestWavelet3D_Projected(t *testing.T) {
+	w := NewWavelet3D(4, 16)
+	// without a normal it's the same as Noise
+	if a, b := w.Projected(1.3, 2.7, 0.4, [3]float64{}), w.Noise(1.3, 2.7, 0.4); a != b {
+		t.Errorf("%g != %g", a, b)
+	}
+	// the normal's length doesn't matter
+	a := w.Projected(1.3, 2.7, 0.4, [3]float64{1, 1, 0})
+	b := w.Projected(1.3, 2.7, 0.4, [3]float64{5, 5, 0})
+	if !nearlyEqual(a, b) {
+		t.Errorf("%g != %g", a, b)
+	}
+}
+
+func TestWavelet_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewWavelet2D(1, 2)
+}
+
+func BenchmarkWavelet3D(b *testing.B) {
+	w := NewWavelet3D(1, 32)
+	for i := 0; i < b.N; i++ {
+		w.Noise(float64(i)*0.137, 1.3, 2.7)
+	}
+}
+
+func BenchmarkWavelet3D_Projected(b *testing.B) {
+	w := NewWavelet3D(1, 32)
+	normal := [3]float64{0.3, 0.5, 0.8}
+	for i := 0; i < b.N; i++ {
+		w.Projected(float64(i)*0.137, 1.3, 2.7, normal)
+	}
+}
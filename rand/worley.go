@@ -0,0 +1,252 @@
+package rand
+
+import (
+	"math"
+
+	"github.com/quillaja/goutil/num"
+)
+
+// Metric selects one of the common distance metrics for Worley2D and
+// Worley3D. They're built in (rather than using data.DistanceMetric)
+// so that evaluating noise doesn't allocate.
+type Metric int
+
+// The distance metrics available to Worley2D and Worley3D.
+// See the functions of the same name in package data.
+const (
+	MetricEuclidean Metric = iota
+	MetricEuclideanSq
+	MetricManhattan
+	MetricChebyshev
+)
+
+// distance of the vector (dx, dy, dz) from the origin.
+func (m Metric) dist(dx, dy, dz float64) float64 {
+	switch m {
+	case MetricEuclideanSq:
+		return dx*dx + dy*dy + dz*dz
+	case MetricManhattan:
+		return math.Abs(dx) + math.Abs(dy) + math.Abs(dz)
+	case MetricChebyshev:
+		return math.Max(math.Abs(dx), math.Max(math.Abs(dy), math.Abs(dz)))
+	default:
+		return math.Sqrt(dx*dx + dy*dy + dz*dz)
+	}
+}
+
+// inserts d into the ascending list of distances f, if it belongs, dropping
+// the largest. returns true if d is the new smallest.
+func insertDistance(f []float64, d float64) bool {
+	last := len(f) - 1
+	if d >= f[last] {
+		return false
+	}
+	i := last
+	for ; i > 0 && f[i-1] > d; i-- {
+		f[i] = f[i-1]
+	}
+	f[i] = d
+	return i == 0
+}
+
+// number of feature points in a cell given the cell's hash.
+func pointsInCell(cdf []float64, maxPtsPerCell int, selection float64) int {
+	npts := maxPtsPerCell
+	for i, cump := range cdf {
+		if selection <= cump {
+			npts = i
+			break
+		}
+	}
+	return num.ClampInt(npts, 1, maxPtsPerCell)
+}
+
+// makes the poisson CDF for the number of points in a cell.
+func poissonCDF(lambda, maxPtsPerCell int) []float64 {
+	cdf := make([]float64, maxPtsPerCell+1, maxPtsPerCell+1)
+	for k, t := 0, 0.0; k <= maxPtsPerCell; k++ {
+		t += num.Poisson(lambda, k)
+		cdf[k] = t
+	}
+	return cdf
+}
+
+// Worley2D is a 2D cell noise generator which, unlike CellNoise2D, keeps
+// no state other than its configuration. Feature points are generated on the
+// fly by hashing the 3x3 neighborhood of cells around each evaluation point,
+// like CellNoiseSlow. It's safe for concurrent use, uses constant memory
+// no matter where it's sampled, and doesn't allocate (except in NoiseN).
+type Worley2D struct {
+	cdf           []float64
+	maxPtsPerCell int
+	metric        Metric
+	key           uint64
+	seed          int64
+}
+
+// NewWorley2D creates a pointer to a new Worley2D struct.
+//
+// Lambda and max determine the number of feature points in a given unit cube, where
+// lambda is the average number per cell and maxPtsPerCell is the maximum number per cell.
+// Metric provides the notion of 'distance'.
+func NewWorley2D(seed int64, lambda, maxPtsPerCell int, metric Metric) *Worley2D {
+	return &Worley2D{
+		cdf:           poissonCDF(lambda, maxPtsPerCell),
+		maxPtsPerCell: maxPtsPerCell,
+		metric:        metric,
+		key:           permKey(MakePermutation(seed)),
+		seed:          seed,
+	}
+}
+
+// Noise gets the distance to the nearest feature point to (x, y), clamped
+// to [0,1]. It's the same as CellNoise2D.Noise().
+func (w *Worley2D) Noise(x, y float64) float64 {
+	var f [1]float64
+	w.search(x, y, f[:])
+	return num.ClampFloat(f[0], 0, 1)
+}
+
+// Features fills f with the distances to the len(f) nearest feature
+// points to (x, y), in ascending order. If fewer points are found, the rest
+// of f is +Inf.
+func (w *Worley2D) Features(x, y float64, f []float64) {
+	if len(f) > 0 {
+		w.search(x, y, f)
+	}
+}
+
+// NoiseN gets the distances to the n nearest feature points to (x, y), as well
+// as the location and ID of the nearest one. Unlike Noise, the distances
+// are not clamped.
+func (w *Worley2D) NoiseN(x, y float64, n int) CellResult {
+	r := CellResult{F: make([]float64, n)}
+	if n > 0 {
+		nx, ny := w.search(x, y, r.F)
+		if r.F = foundFeatures(r.F); len(r.F) > 0 {
+			r.Nearest = []float64{nx, ny}
+			r.ID = featureID(w.seed, nx, ny)
+		}
+	}
+	return r
+}
+
+// finds the nearest len(f) feature points. returns the location of the
+// nearest.
+func (w *Worley2D) search(x, y float64, f []float64) (nx, ny float64) {
+	for i := range f {
+		f[i] = math.Inf(1)
+	}
+
+	// for the cell and 8 cells in its neighborhood
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	for yc := y0 - 1; yc <= y0+1; yc++ {
+		for xc := x0 - 1; xc <= x0+1; xc++ {
+			// reproducible RNG for the cell
+			rng := newCellRNG(w.key, int64(xc), int64(yc))
+
+			// place the cell's feature points, and keep track of the closest
+			for npts := pointsInCell(w.cdf, w.maxPtsPerCell, rng.float64()); npts > 0; npts-- {
+				px := float64(xc) + rng.float64()
+				py := float64(yc) + rng.float64()
+				if insertDistance(f, w.metric.dist(px-x, py-y, 0)) {
+					nx, ny = px, py
+				}
+			}
+		}
+	}
+	return
+}
+
+// trims the +Inf left at the end of f by search when fewer than len(f)
+// feature points were found.
+func foundFeatures(f []float64) []float64 {
+	n := len(f)
+	for n > 0 && math.IsInf(f[n-1], 1) {
+		n--
+	}
+	return f[:n]
+}
+
+// Worley3D is a 3D version of Worley2D, which hashes the 3x3x3 neighborhood
+// of cells around each evaluation point. It's safe for concurrent use.
+type Worley3D struct {
+	cdf           []float64
+	maxPtsPerCell int
+	metric        Metric
+	key           uint64
+	seed          int64
+}
+
+// NewWorley3D creates a pointer to a new Worley3D struct. See NewWorley2D.
+func NewWorley3D(seed int64, lambda, maxPtsPerCell int, metric Metric) *Worley3D {
+	return &Worley3D{
+		cdf:           poissonCDF(lambda, maxPtsPerCell),
+		maxPtsPerCell: maxPtsPerCell,
+		metric:        metric,
+		key:           permKey(MakePermutation(seed)),
+		seed:          seed,
+	}
+}
+
+// Noise gets the distance to the nearest feature point to (x, y, z), clamped
+// to [0,1]. It's the same as CellNoise3D.Noise().
+func (w *Worley3D) Noise(x, y, z float64) float64 {
+	var f [1]float64
+	w.search(x, y, z, f[:])
+	return num.ClampFloat(f[0], 0, 1)
+}
+
+// Features fills f with the distances to the len(f) nearest feature
+// points to (x, y, z), in ascending order. If fewer points are found, the
+// rest of f is +Inf.
+func (w *Worley3D) Features(x, y, z float64, f []float64) {
+	if len(f) > 0 {
+		w.search(x, y, z, f)
+	}
+}
+
+// NoiseN gets the distances to the n nearest feature points to (x, y, z), as
+// well as the location and ID of the nearest one. Unlike Noise, the
+// distances are not clamped.
+func (w *Worley3D) NoiseN(x, y, z float64, n int) CellResult {
+	r := CellResult{F: make([]float64, n)}
+	if n > 0 {
+		near := w.search(x, y, z, r.F)
+		if r.F = foundFeatures(r.F); len(r.F) > 0 {
+			r.Nearest = near[:]
+			r.ID = featureID(w.seed, near[0], near[1], near[2])
+		}
+	}
+	return r
+}
+
+// finds the nearest len(f) feature points. returns the location of the
+// nearest.
+func (w *Worley3D) search(x, y, z float64, f []float64) (near [3]float64) {
+	for i := range f {
+		f[i] = math.Inf(1)
+	}
+
+	// for the cell and 26 cells in its neighborhood
+	x0, y0, z0 := int(math.Floor(x)), int(math.Floor(y)), int(math.Floor(z))
+	for zc := z0 - 1; zc <= z0+1; zc++ {
+		for yc := y0 - 1; yc <= y0+1; yc++ {
+			for xc := x0 - 1; xc <= x0+1; xc++ {
+				// reproducible RNG for the cell
+				rng := newCellRNG(w.key, int64(xc), int64(yc), int64(zc))
+
+				// place the cell's feature points, and keep track of the closest
+				for npts := pointsInCell(w.cdf, w.maxPtsPerCell, rng.float64()); npts > 0; npts-- {
+					px := float64(xc) + rng.float64()
+					py := float64(yc) + rng.float64()
+					pz := float64(zc) + rng.float64()
+					if insertDistance(f, w.metric.dist(px-x, py-y, pz-z)) {
+						near = [3]float64{px, py, pz}
+					}
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,143 @@
+package rand
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/quillaja/goutil/data"
+)
+
+func TestWorley2D_MatchesCellNoise2D(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric Metric
+		dist   data.DistanceMetric
+	}{
+		{name: "euclidean", metric: MetricEuclidean, dist: data.Euclidean},
+		{name: "euclidean sq", metric: MetricEuclideanSq, dist: data.EuclideanSq},
+		{name: "manhattan", metric: MetricManhattan, dist: data.Manhattan},
+		{name: "chebyshev", metric: MetricChebyshev, dist: data.Chebyshev},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorley2D(1, 2, 5, tt.metric)
+			c := NewCellNoise2D(1, 2, 5, tt.dist)
+			for i := 0; i < 2500; i++ {
+				x, y := float64(i%50)*0.061, float64(i/50)*0.057
+				if a, b := w.Noise(x, y), c.Noise(x, y); !nearlyEqual(a, b) {
+					t.Fatalf("(%g,%g): %g != %g", x, y, a, b)
+				}
+				wr, cr := w.NoiseN(x, y, 3), c.NoiseN(x, y, 3)
+				for k := range wr.F {
+					if !nearlyEqual(wr.F[k], cr.F[k]) {
+						t.Fatalf("(%g,%g) F%d: %g != %g", x, y, k+1, wr.F[k], cr.F[k])
+					}
+				}
+				if wr.ID != cr.ID {
+					t.Fatalf("(%g,%g) ID: %d != %d", x, y, wr.ID, cr.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestWorley3D_MatchesCellNoise3D(t *testing.T) {
+	w := NewWorley3D(1, 2, 5, MetricEuclidean)
+	c := NewCellNoise3D(1, 2, 5, data.Euclidean)
+	for i := 0; i < 2500; i++ {
+		x, y, z := float64(i%50)*0.061, float64(i/50)*0.057, 0.5
+		if a, b := w.Noise(x, y, z), c.Noise(x, y, z); !nearlyEqual(a, b) {
+			t.Fatalf("(%g,%g,%g): %g != %g", x, y, z, a, b)
+		}
+	}
+}
+
+func TestWorley_NoiseNTrimmed(t *testing.T) {
+	// with 1 point per cell only 9 (2D) or 27 (3D) points are searched, so
+	// asking for more gives just the points found, as CellNoise2D does.
+	w2 := NewWorley2D(1, 1, 1, MetricEuclidean)
+	w3 := NewWorley3D(1, 1, 1, MetricEuclidean)
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.37, float64(i)*0.19, float64(i)*0.11
+		if r := w2.NoiseN(x, y, 20); len(r.F) != 9 || r.Nearest == nil {
+			t.Fatalf("2D (%g,%g): got %d features, nearest %v", x, y, len(r.F), r.Nearest)
+		}
+		if r := w3.NoiseN(x, y, z, 40); len(r.F) != 27 || r.Nearest == nil {
+			t.Fatalf("3D (%g,%g,%g): got %d features, nearest %v", x, y, z, len(r.F), r.Nearest)
+		}
+	}
+}
+
+func TestWorley2D_Concurrent(t *testing.T) {
+	w := NewWorley2D(1, 2, 5, MetricEuclidean)
+	want := make([]float64, 1000)
+	for i := range want {
+		want[i] = w.Noise(float64(i)*0.37-100, float64(i)*0.23+100)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range want {
+				if got := w.Noise(float64(i)*0.37-100, float64(i)*0.23+100); got != want[i] {
+					t.Errorf("%d: %g != %g", i, got, want[i])
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWorley_NoAlloc(t *testing.T) {
+	w2 := NewWorley2D(1, 2, 5, MetricEuclidean)
+	w3 := NewWorley3D(1, 2, 5, MetricEuclidean)
+	f := make([]float64, 3)
+	allocs := testing.AllocsPerRun(100, func() {
+		w2.Noise(1.5, 2.5)
+		w2.Features(1.5, 2.5, f)
+		w3.Noise(1.5, 2.5, 3.5)
+		w3.Features(1.5, 2.5, 3.5, f)
+	})
+	if allocs != 0 {
+		t.Errorf("%g allocations", allocs)
+	}
+}
+
+// floating point comparison, since Worley and CellNoise calculate
+// distances in a different order.
+func nearlyEqual(a, b float64) bool {
+	d := a - b
+	return -1e-12 < d && d < 1e-12
+}
+
+func BenchmarkWorley2D(b *testing.B) {
+	const m = 10
+	noise := NewWorley2D(0, 2, 5, MetricEuclideanSq)
+	for n := 0; n < b.N; n++ {
+		offset := m * float64(n) / float64(b.N)
+		noise.Noise(offset, offset)
+	}
+}
+
+func BenchmarkWorley3D(b *testing.B) {
+	const m = 10
+	noise := NewWorley3D(0, 2, 5, MetricEuclideanSq)
+	for n := 0; n < b.N; n++ {
+		offset := m * float64(n) / float64(b.N)
+		noise.Noise(offset, offset, offset)
+	}
+}
+
+func BenchmarkWorley2D_Parallel(b *testing.B) {
+	noise := NewWorley2D(0, 2, 5, MetricEuclideanSq)
+	b.RunParallel(func(pb *testing.PB) {
+		offset := 0.0
+		for pb.Next() {
+			noise.Noise(offset, offset)
+			offset += 0.001
+		}
+	})
+}